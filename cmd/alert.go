@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// notifyTimeout bounds how long a single notify hook may run.
+const notifyTimeout = 10 * time.Second
+
+// alertEvent describes an alert changing state. It is POSTed as JSON to
+// webhook hooks and passed to command hooks as SYSTAT_* environment variables.
+type alertEvent struct {
+	Name      string  `json:"name"`
+	State     string  `json:"state"`
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+}
+
+// alerter evaluates thresholds and runs the notify hook when an alert
+// transitions between firing and resolved, rather than on every sample.
+//
+// A nil *alerter is valid and ignores every observation, so callers don't
+// need to check whether notifications are enabled.
+type alerter struct {
+	hook         string
+	slackWebhook string
+	logger       *log.Logger
+
+	mu     sync.Mutex
+	firing map[string]bool
+	wg     sync.WaitGroup
+}
+
+// alerts is set up by the root command when a notify hook or Slack webhook
+// is configured.
+var alerts *alerter
+
+func newAlerter(hook, slackWebhook string, logger *log.Logger) *alerter {
+	return &alerter{
+		hook:         hook,
+		slackWebhook: slackWebhook,
+		logger:       logger,
+		firing:       make(map[string]bool),
+	}
+}
+
+// Threshold fires name when value exceeds threshold. A threshold of zero
+// disables the alert.
+func (a *alerter) Threshold(name, metric string, value, threshold float64) {
+	if a == nil || threshold <= 0 {
+		return
+	}
+	a.update(alertEvent{
+		Name:      name,
+		Metric:    metric,
+		Value:     value,
+		Threshold: threshold,
+	}, value > threshold)
+}
+
+// Check fires name while a status check is failing.
+func (a *alerter) Check(name string, ok bool) {
+	if a == nil {
+		return
+	}
+	value := 0.0
+	if ok {
+		value = 1
+	}
+	a.update(alertEvent{
+		Name:      name,
+		Metric:    "status",
+		Value:     value,
+		Threshold: 1,
+	}, !ok)
+}
+
+func (a *alerter) update(event alertEvent, firing bool) {
+	a.mu.Lock()
+	was, seen := a.firing[event.Name]
+	a.firing[event.Name] = firing
+	a.mu.Unlock()
+
+	// Only transitions notify. An alert that starts out healthy is silent.
+	if firing == was || (!seen && !firing) {
+		return
+	}
+
+	event.State = "resolved"
+	if firing {
+		event.State = "firing"
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		if err := a.notify(event); err != nil {
+			a.logger.Warn("notify hook failed", "alert", event.Name, "error", err)
+		}
+	}()
+}
+
+// Wait blocks until in-flight notifications have finished.
+func (a *alerter) Wait() {
+	if a == nil {
+		return
+	}
+	a.wg.Wait()
+}
+
+func (a *alerter) notify(event alertEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	a.logger.Debug("alert", "name", event.Name, "state", event.State, "value", event.Value)
+
+	var errs []error
+	if a.hook != "" {
+		errs = append(errs, runHook(ctx, a.hook, event))
+	}
+	if a.slackWebhook != "" {
+		errs = append(errs, postWebhook(ctx, a.slackWebhook, slackMessage(event)))
+	}
+	return errors.Join(errs...)
+}
+
+// runHook delivers event to a notify hook, which is either a URL to POST
+// the event to or a shell command.
+func runHook(ctx context.Context, hook string, event alertEvent) error {
+	if strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://") {
+		return postWebhook(ctx, hook, event)
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook)
+	cmd.Env = append(os.Environ(),
+		"SYSTAT_ALERT="+event.Name,
+		"SYSTAT_STATE="+event.State,
+		"SYSTAT_METRIC="+event.Metric,
+		"SYSTAT_VALUE="+strconv.FormatFloat(event.Value, 'f', -1, 64),
+		"SYSTAT_THRESHOLD="+strconv.FormatFloat(event.Threshold, 'f', -1, 64),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// slackPayload is an incoming-webhook message. Slack reads text and Discord
+// reads content, so filling both lets one payload serve either service.
+type slackPayload struct {
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+func slackMessage(event alertEvent) slackPayload {
+	var msg string
+	switch {
+	case event.Metric == "status" && event.State == "firing":
+		msg = fmt.Sprintf(":rotating_light: *%s* check is failing", event.Name)
+	case event.Metric == "status":
+		msg = fmt.Sprintf(":white_check_mark: *%s* check recovered", event.Name)
+	case event.State == "firing":
+		msg = fmt.Sprintf(":rotating_light: *%s* is firing: %s is %.1f (threshold %g)",
+			event.Name, event.Metric, event.Value, event.Threshold)
+	default:
+		msg = fmt.Sprintf(":white_check_mark: *%s* resolved: %s is %.1f (threshold %g)",
+			event.Name, event.Metric, event.Value, event.Threshold)
+	}
+	if host := hostTag(); host != "" {
+		msg += " on " + host
+	}
+	return slackPayload{Text: msg, Content: msg}
+}
+
+// postWebhook POSTs payload to url as JSON.
+func postWebhook(ctx context.Context, url string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// procRoot is where Linux exposes per-process information.
+	procRoot = "/proc"
+	// dockerContainersRoot holds a directory per Docker container, named by
+	// its ID, with the container's name in config.v2.json.
+	dockerContainersRoot = "/var/lib/docker/containers"
+)
+
+// containerIDPattern matches the 64 hex character IDs Docker, containerd,
+// CRI-O and Podman give containers, which they all put in the cgroup path.
+var containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// processCgroup is the cgroup a process is in and, when that cgroup belongs
+// to a container, which one.
+type processCgroup struct {
+	Path             string `json:"path"`
+	ContainerID      string `json:"container_id,omitempty"`
+	ContainerRuntime string `json:"container_runtime,omitempty"`
+	// ContainerName is only known for Docker containers, whose metadata is
+	// on disk; the other runtimes keep it behind their APIs.
+	ContainerName string `json:"container_name,omitempty"`
+}
+
+// String shows the container name, or the runtime and short ID, for
+// processes in containers and the cgroup path for the rest.
+func (c processCgroup) String() string {
+	switch {
+	case c.ContainerName != "":
+		return c.ContainerName
+	case c.ContainerID != "":
+		return c.ContainerRuntime + ":" + c.ContainerID[:12]
+	default:
+		return c.Path
+	}
+}
+
+// dockerNames caches container names by ID across refreshes in watch mode.
+// Only hits are kept, so containers started since are still looked up.
+var dockerNames = map[string]string{}
+
+// readProcessCgroup reads /proc/<pid>/cgroup. With cgroup v2 there's a single
+// line, "0::/system.slice/docker-<id>.scope"; with v1 there's one per
+// controller, "4:memory:/docker/<id>", and the first is used since a
+// container's ID is in all of them.
+func readProcessCgroup(pid int32) (processCgroup, error) {
+	f, err := os.Open(filepath.Join(procRoot, strconv.Itoa(int(pid)), "cgroup"))
+	if err != nil {
+		return processCgroup{}, fmt.Errorf("failed to read cgroup of %d: %w", pid, err)
+	}
+	defer f.Close()
+
+	var cg processCgroup
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			cg.Path = fields[2]
+			break
+		}
+		if cg.Path == "" {
+			cg.Path = fields[2]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return processCgroup{}, fmt.Errorf("failed to read cgroup of %d: %w", pid, err)
+	}
+
+	if id := containerIDPattern.FindString(cg.Path); id != "" {
+		cg.ContainerID = id
+		cg.ContainerRuntime = containerRuntime(cg.Path)
+		if cg.ContainerRuntime == "docker" {
+			cg.ContainerName = dockerName(id)
+		}
+	}
+	return cg, nil
+}
+
+// containerRuntime guesses which runtime manages a container from the names
+// it gives the container's cgroup.
+func containerRuntime(path string) string {
+	switch {
+	case strings.Contains(path, "docker"):
+		return "docker"
+	case strings.Contains(path, "containerd"):
+		return "containerd"
+	case strings.Contains(path, "crio"):
+		return "cri-o"
+	case strings.Contains(path, "libpod"):
+		return "podman"
+	default:
+		return "container"
+	}
+}
+
+// dockerName returns the name of a Docker container, or "" when its
+// metadata can't be read, which usually takes root.
+func dockerName(id string) string {
+	if name, ok := dockerNames[id]; ok {
+		return name
+	}
+
+	b, err := os.ReadFile(filepath.Join(dockerContainersRoot, id, "config.v2.json"))
+	if err != nil {
+		return ""
+	}
+	var config struct {
+		Name string `json:"Name"`
+	}
+	if err := json.Unmarshal(b, &config); err != nil || config.Name == "" {
+		return ""
+	}
+	name := strings.TrimPrefix(config.Name, "/")
+	dockerNames[id] = name
+	return name
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// maxConcurrentScripts bounds how many check scripts run at once, so a
+	// directory full of them doesn't fork them all together.
+	maxConcurrentScripts = 4
+	// maxStatusLine bounds the status line kept from a script's output.
+	maxStatusLine = 200
+)
+
+// scriptSlots is a semaphore holding a slot per running check script.
+var scriptSlots = make(chan struct{}, maxConcurrentScripts)
+
+// checkScriptsConfig is a directory of executable scripts, each run as a
+// status check, configured under "check_scripts" in the config file.
+type checkScriptsConfig struct {
+	// Dir is the directory to scan, e.g. ~/.config/systat/checks.d. A
+	// relative path is taken from the config file's directory.
+	Dir string `yaml:"dir"`
+	// Interval and Timeout apply to every script, as they would to a check.
+	Interval time.Duration `yaml:"interval"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// discoverCheckScripts lists the executable files in the scripts directory
+// as script checks, named after the file without its extension. Hidden
+// files and anything not executable, such as a README, are skipped.
+func discoverCheckScripts(c checkScriptsConfig, configDir string) ([]checkConfig, error) {
+	dir := c.Dir
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand %s: %w", dir, err)
+		}
+		dir = filepath.Join(home, rest)
+	} else if !filepath.IsAbs(dir) {
+		dir = filepath.Join(configDir, dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read check scripts: %w", err)
+	}
+
+	var checks []checkConfig
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		// Stat rather than e.Info() so symlinked scripts count.
+		path := filepath.Join(dir, e.Name())
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		checks = append(checks, checkConfig{
+			Name:     strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())),
+			Type:     "script",
+			Target:   path,
+			Interval: c.Interval,
+			Timeout:  c.Timeout,
+		})
+	}
+	return checks, nil
+}
+
+// scriptCheck runs a check script, which passes when it exits zero. The
+// first non-empty line it prints is its status line, e.g. "OK - 3 backups
+// fresh", and stands in for the exit status in the error when it fails.
+func scriptCheck(ctx context.Context, path string) (string, error) {
+	cmd := exec.CommandContext(ctx, path)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// A script that leaves a background child holding stdout open would
+	// otherwise keep the check waiting after the timeout has killed it.
+	cmd.WaitDelay = time.Second
+	err := cmd.Run()
+
+	status := statusLine(stdout.Bytes())
+	var exitErr *exec.ExitError
+	if status != "" && errors.As(err, &exitErr) {
+		err = errors.New(status)
+	}
+	return status, err
+}
+
+// statusLine returns the first non-empty line of out, cut to maxStatusLine.
+func statusLine(out []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if r := []rune(line); len(r) > maxStatusLine {
+			line = string(r[:maxStatusLine-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// writeScript writes a shell script to dir/name with the given mode.
+func writeScript(t *testing.T, dir, name, body string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscoverCheckScripts(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "backup.sh", "exit 0", 0o755)
+	writeScript(t, dir, "disk", "exit 0", 0o700)
+	writeScript(t, dir, ".hidden", "exit 0", 0o755)
+	writeScript(t, dir, "README", "notes", 0o644)
+	if err := os.Mkdir(filepath.Join(dir, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A symlink into another directory, as when scripts are kept with
+	// the services they check.
+	other := t.TempDir()
+	target := writeScript(t, other, "ping-db", "exit 0", 0o755)
+	if err := os.Symlink(target, filepath.Join(dir, "db.sh")); err != nil {
+		t.Fatal(err)
+	}
+
+	checks, err := discoverCheckScripts(checkScriptsConfig{Dir: dir, Timeout: defaultCheckTimeout}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range checks {
+		if c.Type != "script" || c.Timeout != defaultCheckTimeout {
+			t.Errorf("check %q: got type %q, timeout %s", c.Name, c.Type, c.Timeout)
+		}
+		got = append(got, c.Name+"="+filepath.Base(c.Target))
+	}
+	want := []string{"backup=backup.sh", "db=db.sh", "disk=disk"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got checks %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverCheckScriptsRelativeToConfig(t *testing.T) {
+	configDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(configDir, "checks.d"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeScript(t, filepath.Join(configDir, "checks.d"), "up", "exit 0", 0o755)
+
+	checks, err := discoverCheckScripts(checkScriptsConfig{Dir: "checks.d"}, configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 1 || checks[0].Target != filepath.Join(configDir, "checks.d", "up") {
+		t.Errorf("got %+v, want the script under the config directory", checks)
+	}
+
+	if _, err := discoverCheckScripts(checkScriptsConfig{Dir: "missing"}, configDir); err == nil {
+		t.Error("got no error for a missing directory")
+	}
+}
+
+func TestCheckScriptNamesMustBeUnique(t *testing.T) {
+	tests := []struct {
+		name    string
+		checks  string
+		wantErr bool
+	}{
+		// Scripts run alongside the defaults when no checks are set.
+		{"clash with a default check", "", true},
+		{"clash with a configured check", "checks: [{type: dns, target: runtime.uds.dev}]\n", true},
+		{"configured checks replace the defaults", "checks: [{type: dns, target: example.com}]\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			scripts := filepath.Join(configDir, "checks.d")
+			if err := os.Mkdir(scripts, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			writeScript(t, scripts, "runtime.uds.dev.sh", "exit 0", 0o755)
+			path := filepath.Join(configDir, "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.checks+"check_scripts: {dir: checks.d}\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := loadConfig(path, true)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "duplicate name") {
+					t.Errorf("got error %v, want a duplicate name", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(c.Checks) != 1 || len(c.scriptChecks) != 1 {
+				t.Errorf("got %d checks and %d scripts, want 1 of each", len(c.Checks), len(c.scriptChecks))
+			}
+		})
+	}
+}
+
+func TestStatusLine(t *testing.T) {
+	long := strings.Repeat("é", maxStatusLine+10)
+
+	tests := []struct {
+		name string
+		out  string
+		want string
+	}{
+		{"empty", "", ""},
+		{"first non-empty line", "\n  \nOK - fine  \nmore\n", "OK - fine"},
+		{"exactly the limit", strings.Repeat("é", maxStatusLine), strings.Repeat("é", maxStatusLine)},
+		{"truncated on a rune boundary", long, strings.Repeat("é", maxStatusLine-3) + "..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := statusLine([]byte(tt.out))
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) || utf8.RuneCountInString(got) > maxStatusLine {
+				t.Errorf("got %d runes of valid UTF-8 %v, want at most %d", utf8.RuneCountInString(got), utf8.ValidString(got), maxStatusLine)
+			}
+		})
+	}
+}
+
+func TestScriptCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus string
+		wantErr    string
+	}{
+		{"passes", `echo "OK - 3 backups fresh"`, "OK - 3 backups fresh", ""},
+		{"status line replaces exit status", "echo 'CRITICAL - disk full'; exit 2", "CRITICAL - disk full", "CRITICAL - disk full"},
+		{"exit status without a status line", "exit 1", "", "exit status 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeScript(t, dir, strings.ReplaceAll(tt.name, " ", "-"), tt.body, 0o755)
+
+			status, err := scriptCheck(context.Background(), path)
+			if status != tt.wantStatus {
+				t.Errorf("got status %q, want %q", status, tt.wantStatus)
+			}
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("got error %q, want %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScriptChecksAreBounded(t *testing.T) {
+	dir := t.TempDir()
+	running := t.TempDir()
+
+	// Each script marks itself running while it sleeps and logs how many
+	// were running when it started.
+	body := `mkdir "` + running + `/$$"; ls "` + running + `" | wc -l >> "` + dir + `/counts"; sleep 0.2; rmdir "` + running + `/$$"`
+	var checks []checkConfig
+	for i := range 2 * maxConcurrentScripts {
+		name := "s" + strings.Repeat("x", i)
+		path := writeScript(t, dir, name, body, 0o755)
+		checks = append(checks, checkConfig{Name: name, Type: "script", Target: path, Timeout: defaultCheckTimeout})
+	}
+
+	for _, r := range runChecks(context.Background(), checks) {
+		if !r.OK {
+			t.Fatalf("check %q failed: %s", r.Name, r.Error)
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "counts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range strings.Fields(string(b)) {
+		if n, _ := strconv.Atoi(field); n > maxConcurrentScripts {
+			t.Errorf("%d scripts ran at once, want at most %d", n, maxConcurrentScripts)
+		}
+	}
+}
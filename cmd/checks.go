@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCheckInterval is how often a check runs unless it sets its own
+	// interval.
+	defaultCheckInterval = 5 * time.Second
+	// defaultCheckTimeout bounds a check that doesn't set its own timeout.
+	defaultCheckTimeout = 5 * time.Second
+)
+
+// checkConfig is a status check run by the dashboard and `systat status`,
+// configured under "checks" in the config file.
+type checkConfig struct {
+	// Name labels the check; it defaults to the target for DNS, HTTP and
+	// script checks and "<type> <target>" for the others.
+	Name string `yaml:"name"`
+	// Type is the kind of check: dns, ping, tcp, http, command or script.
+	Type string `yaml:"type"`
+	// Target is the host name to resolve, the host to ping, the host:port
+	// to connect to, the URL to fetch, the host substituted into Command,
+	// or the script to run.
+	Target string `yaml:"target"`
+	// ExpectStatus is the status code an http check must get; by default
+	// any 2xx passes.
+	ExpectStatus int `yaml:"expect_status"`
+	// Command is a shell command for command checks, passing when it exits
+	// zero, e.g. "nc -z {host} 443". {host} is replaced by Target.
+	Command string `yaml:"command"`
+	// Interval is how often the check runs, e.g. 5s or 1m.
+	Interval time.Duration `yaml:"interval"`
+	// Timeout is how long the check may take before it counts as failed.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// defaultChecks are used when the config file doesn't list any.
+var defaultChecks = []checkConfig{
+	{Name: "runtime.uds.dev", Type: "dns", Target: "runtime.uds.dev", Interval: defaultCheckInterval, Timeout: defaultCheckTimeout},
+	{Name: "keycloak.admin.uds.dev", Type: "dns", Target: "keycloak.admin.uds.dev", Interval: defaultCheckInterval, Timeout: defaultCheckTimeout},
+	{Name: "ping 10.0.0.1", Type: "ping", Target: "10.0.0.1", Interval: defaultCheckInterval, Timeout: defaultCheckTimeout},
+}
+
+// configuredChecks returns the checks from the config file, or the defaults,
+// followed by any found in the check scripts directory.
+func configuredChecks() []checkConfig {
+	checks := defaultChecks
+	if len(cfg.Checks) > 0 {
+		checks = cfg.Checks
+	}
+	return append(slices.Clip(checks), cfg.scriptChecks...)
+}
+
+// normalizeChecks validates checks read from the config file and fills in
+// default names, intervals and timeouts.
+func normalizeChecks(checks []checkConfig) error {
+	seen := make(map[string]bool, len(checks))
+	for i := range checks {
+		c := &checks[i]
+		switch c.Type {
+		case "dns", "ping":
+		case "tcp":
+			if _, _, err := net.SplitHostPort(c.Target); err != nil {
+				return fmt.Errorf("check %d: tcp target must be host:port: %w", i+1, err)
+			}
+		case "http":
+			if u, err := url.Parse(c.Target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("check %d: http target must be an http or https URL, got %q", i+1, c.Target)
+			}
+		case "command":
+			if c.Command == "" {
+				return fmt.Errorf("check %d: command is required for command checks", i+1)
+			}
+		case "script":
+		default:
+			return fmt.Errorf("check %d: invalid type %q: must be one of dns, ping, tcp, http, command, script", i+1, c.Type)
+		}
+		if c.Target == "" {
+			return fmt.Errorf("check %d: target is required", i+1)
+		}
+		if c.Name == "" {
+			c.Name = c.Type + " " + c.Target
+			if c.Type == "dns" || c.Type == "http" || c.Type == "script" {
+				c.Name = c.Target
+			}
+		}
+		if c.Interval < 0 || c.Timeout < 0 {
+			return fmt.Errorf("check %q: interval and timeout must not be negative", c.Name)
+		}
+		if c.Interval == 0 {
+			c.Interval = defaultCheckInterval
+		}
+		if c.Timeout == 0 {
+			c.Timeout = defaultCheckTimeout
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("check %d: duplicate name %q", i+1, c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+// checkResult is the outcome of running one check.
+type checkResult struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	OK     bool   `json:"ok"`
+	// Code is the HTTP status code an http check got.
+	Code int `json:"status_code,omitempty"`
+	// Message is the status line a script check printed.
+	Message   string        `json:"message,omitempty"`
+	LatencyMs float64       `json:"latency_ms"`
+	Error     string        `json:"error,omitempty"`
+	Took      time.Duration `json:"-"`
+}
+
+// runCheck runs c once, giving up after its timeout.
+func runCheck(ctx context.Context, c checkConfig) checkResult {
+	// Waiting for a slot doesn't count against the script's timeout.
+	if c.Type == "script" {
+		scriptSlots <- struct{}{}
+		defer func() { <-scriptSlots }()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	var code int
+	var message string
+	switch c.Type {
+	case "dns":
+		_, err = net.DefaultResolver.LookupHost(ctx, c.Target)
+	case "ping":
+		// ping's own deadline is in whole seconds; the context enforces
+		// the exact timeout.
+		wait := max(1, int(c.Timeout.Seconds()))
+		err = exec.CommandContext(ctx, "ping", "-c", "1", "-W", strconv.Itoa(wait), c.Target).Run()
+	case "tcp":
+		// A completed handshake means something is accepting connections,
+		// which says more about a service than ICMP does.
+		var conn net.Conn
+		if conn, err = new(net.Dialer).DialContext(ctx, "tcp", c.Target); err == nil {
+			conn.Close()
+		}
+	case "http":
+		code, err = httpCheck(ctx, c.Target, c.ExpectStatus)
+	case "command":
+		err = commandCheck(ctx, c.Command, c.Target).Run()
+	case "script":
+		message, err = scriptCheck(ctx, c.Target)
+	default:
+		err = fmt.Errorf("unknown check type %q", c.Type)
+	}
+
+	result := checkResult{
+		Name:    c.Name,
+		Type:    c.Type,
+		Target:  c.Target,
+		OK:      err == nil,
+		Code:    code,
+		Message: message,
+		Took:    time.Since(start),
+	}
+	result.LatencyMs = float64(result.Took.Microseconds()) / 1000
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// httpCheck fetches target and returns the status code, failing unless it's
+// expect or, when expect is zero, any 2xx.
+func httpCheck(ctx context.Context, target string, expect int) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	code := resp.StatusCode
+	if expect != 0 && code != expect {
+		return code, fmt.Errorf("got status %d, want %d", code, expect)
+	}
+	if expect == 0 && (code < 200 || code > 299) {
+		return code, fmt.Errorf("got status %d", code)
+	}
+	return code, nil
+}
+
+// commandCheck builds the command for a command check. The host is passed
+// to the shell as $1 rather than pasted into the script, so it can't be
+// interpreted as shell syntax.
+func commandCheck(ctx context.Context, template, host string) *exec.Cmd {
+	script := strings.ReplaceAll(template, "{host}", `"$1"`)
+	return exec.CommandContext(ctx, "sh", "-c", script, "systat-check", host)
+}
+
+// runChecks runs all checks concurrently and returns their results in the
+// same order.
+func runChecks(ctx context.Context, checks []checkConfig) []checkResult {
+	results := make([]checkResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// checkSchedule runs each check no more often than its interval, for watch
+// mode, and keeps the latest result of those not yet due again.
+type checkSchedule struct {
+	checks []checkConfig
+	last   map[string]checkResult
+	next   map[string]time.Time
+}
+
+func newCheckSchedule(checks []checkConfig) *checkSchedule {
+	return &checkSchedule{
+		checks: checks,
+		last:   make(map[string]checkResult, len(checks)),
+		next:   make(map[string]time.Time, len(checks)),
+	}
+}
+
+// Run runs the checks that are due and returns the latest result of every
+// check, in configuration order.
+func (s *checkSchedule) Run(ctx context.Context) []checkResult {
+	now := time.Now()
+	var due []checkConfig
+	for _, c := range s.checks {
+		if !now.Before(s.next[c.Name]) {
+			due = append(due, c)
+			s.next[c.Name] = now.Add(c.Interval)
+		}
+	}
+	// Only fresh results count towards the session's latencies; the others
+	// were already observed when they ran.
+	for _, r := range runChecks(ctx, due) {
+		s.last[r.Name] = r
+		watchStats.Observe(r.Name+" latency", r.LatencyMs, formatMs)
+	}
+
+	results := make([]checkResult, 0, len(s.checks))
+	for _, c := range s.checks {
+		results = append(results, s.last[c.Name])
+	}
+	return results
+}
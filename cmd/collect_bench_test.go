@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+// Run with go test -run '^$' -bench . -benchmem ./cmd to see the
+// allocations each collector makes per sample.
+
+func BenchmarkCollectMetrics(b *testing.B) {
+	// The first sample only takes a CPU baseline and waits a second for
+	// the next; later ones measure since the previous call, as in watch
+	// mode.
+	logger := log.New(io.Discard)
+	if _, err := collectMetrics(logger); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := collectMetrics(logger); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCollectDisk(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := collectDisk(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCollectProcesses(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := collectProcesses(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// columnDoc describes a table column for --list-columns.
+type columnDoc struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+var listColumns bool
+
+// registerColumns documents the table columns cmd can show and gives it a
+// --list-columns flag that prints them instead of running the command, so
+// scripts can discover column names rather than hardcoding them.
+//
+// It wraps cmd.RunE, so call it from init after the command is declared.
+func registerColumns(cmd *cobra.Command, columns []columnDoc) {
+	cmd.Flags().BoolVar(&listColumns, "list-columns", false, "list the table columns this command can show and exit")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if listColumns {
+			return printColumns(columns)
+		}
+		return run(cmd, args)
+	}
+}
+
+func printColumns(columns []columnDoc) error {
+	if structuredOutput() {
+		return renderStructured(columns)
+	}
+
+	for _, col := range columns {
+		fmt.Printf("%-12s %-14s %s\n", col.Name, col.Title, col.Description)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config is the optional configuration file, by default
+// $XDG_CONFIG_HOME/systat/config.yaml.
+type config struct {
+	// Notify is the command to run, or URL to POST to, when an alert
+	// changes state. It can be overridden with --notify.
+	Notify string `yaml:"notify"`
+	// SlackWebhook is a Slack (or Discord) incoming webhook URL that
+	// receives a formatted message when an alert changes state.
+	SlackWebhook string          `yaml:"slack_webhook"`
+	Thresholds   thresholds      `yaml:"thresholds"`
+	Dashboard    dashboardConfig `yaml:"dashboard"`
+	// AbsoluteTime shows timestamps instead of ages, like --absolute-time.
+	AbsoluteTime bool `yaml:"absolute_time"`
+	// Checks are the dns, ping, tcp, http, command and script checks shown
+	// by the dashboard and `systat status`. The built-in defaults are used
+	// when none are set.
+	Checks []checkConfig `yaml:"checks"`
+	// CheckScripts is a directory of scripts run as checks alongside Checks.
+	CheckScripts checkScriptsConfig `yaml:"check_scripts"`
+
+	// scriptChecks are the checks found in CheckScripts.Dir at startup.
+	scriptChecks []checkConfig
+}
+
+// dashboardConfig holds dashboard preferences; flags of the same name
+// override them.
+type dashboardConfig struct {
+	// Bell rings the terminal bell when a status check changes state.
+	Bell bool `yaml:"bell"`
+	// Flash briefly highlights the Status section when a check changes.
+	Flash bool `yaml:"flash"`
+}
+
+// thresholds are the alerting limits; a zero value disables the alert.
+type thresholds struct {
+	DiskPercent float64 `yaml:"disk_percent"`
+	Load1       float64 `yaml:"load1"`
+}
+
+var (
+	configPath string
+	cfg        config
+)
+
+// defaultConfigPath returns the config file location used when --config
+// isn't given, or "" if the user config directory can't be determined.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "systat", "config.yaml")
+}
+
+// loadConfig reads the config file at path. A missing file is only an error
+// when the path was given explicitly.
+func loadConfig(path string, explicit bool) (config, error) {
+	var c config
+	if path == "" {
+		return c, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return c, nil
+		}
+		return c, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if c.CheckScripts.Dir != "" {
+		scripts, err := discoverCheckScripts(c.CheckScripts, filepath.Dir(path))
+		if err != nil {
+			return c, fmt.Errorf("invalid config %s: %w", path, err)
+		}
+		c.scriptChecks = scripts
+	}
+	// Scripts are validated with the checks they run alongside, the
+	// defaults when none are set, so their names can't clash, then split
+	// off again.
+	base := c.Checks
+	if len(base) == 0 {
+		base = defaultChecks
+	}
+	checks := slices.Concat(base, c.scriptChecks)
+	if err := normalizeChecks(checks); err != nil {
+		return c, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if len(c.Checks) > 0 {
+		c.Checks = checks[:len(c.Checks)]
+	}
+	c.scriptChecks = checks[len(base):]
+	return c, nil
+}
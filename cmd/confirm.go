@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dryRun    bool
+	assumeYes bool
+)
+
+// registerWriteFlags adds --dry-run and --yes to a subcommand that changes
+// system state. Such commands call confirm before acting.
+func registerWriteFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print what would be done without doing it")
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "don't ask for confirmation")
+}
+
+// confirm decides whether to go ahead with action, described like "renice
+// process 1234 from 0 to 10". With --dry-run it prints the action and
+// returns false. Otherwise it asks on the terminal unless --yes is set, and
+// refuses when there's no terminal to ask on.
+func confirm(action string) (bool, error) {
+	if dryRun {
+		fmt.Printf("would %s (dry run)\n", action)
+		return false, nil
+	}
+	if assumeYes {
+		return true, nil
+	}
+	if !isTerminal(os.Stdin) {
+		return false, fmt.Errorf("refusing to %s without confirmation: pass --yes", action)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s? [y/N] ", capitalize(action))
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
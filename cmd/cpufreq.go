@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cpuRoot is where Linux exposes per-CPU frequency scaling.
+const cpuRoot = "/sys/devices/system/cpu"
+
+// cpuFreq is one CPU's clock speed. BaseMHz is its rated base clock, and 0
+// when the driver doesn't report one.
+type cpuFreq struct {
+	CPU     int     `json:"cpu"`
+	CurMHz  float64 `json:"current_mhz"`
+	BaseMHz float64 `json:"base_mhz,omitempty"`
+}
+
+// readCPUFreqs returns each CPU's current frequency, ordered by CPU number.
+// Systems without cpufreq, such as most VMs and containers, report none.
+func readCPUFreqs() ([]cpuFreq, error) {
+	files, err := filepath.Glob(filepath.Join(cpuRoot, "cpu[0-9]*", "cpufreq", "scaling_cur_freq"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CPU frequencies: %w", err)
+	}
+
+	var freqs []cpuFreq
+	for _, file := range files {
+		dir := filepath.Dir(filepath.Dir(file))
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "cpu"))
+		if err != nil {
+			continue
+		}
+		// cpufreq reports kHz.
+		khz, err := readSysfsUint(file)
+		if err != nil {
+			// The CPU went offline between listing and reading.
+			continue
+		}
+		freq := cpuFreq{CPU: id, CurMHz: float64(khz) / 1000}
+		// base_frequency is only reported by intel_pstate. The maximum
+		// isn't a stand-in: it includes turbo, which cores run below
+		// whenever they're idle, so throttling is left unknown instead.
+		if base, err := readSysfsUint(filepath.Join(filepath.Dir(file), "base_frequency")); err == nil {
+			freq.BaseMHz = float64(base) / 1000
+		}
+		freqs = append(freqs, freq)
+	}
+
+	sort.Slice(freqs, func(i, j int) bool {
+		return freqs[i].CPU < freqs[j].CPU
+	})
+	return freqs, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// cpuSampler measures CPU usage between successive calls from cumulative CPU
+// times, the way top does.
+//
+// cpu.Percent with an interval sleeps for that interval on every call, which
+// would stall the dashboard's tick and add a second to every watch-mode
+// sample. A sampler only has to wait once, for its first baseline; after
+// that each reading covers the time since the previous one and returns
+// immediately.
+type cpuSampler struct {
+	perCPU bool
+
+	mu   sync.Mutex
+	prev []cpu.TimesStat
+}
+
+func newCPUSampler(perCPU bool) *cpuSampler {
+	return &cpuSampler{perCPU: perCPU}
+}
+
+// cpuTotal samples overall CPU usage for the metrics command, so watch mode
+// measures each interval without blocking again.
+var cpuTotal = newCPUSampler(false)
+
+// Sample returns usage percentages since the previous call, one per CPU or a
+// single total. ok is false on the first call, which only records a baseline.
+func (s *cpuSampler) Sample() (percents []float64, ok bool, err error) {
+	times, err := cpu.Times(s.perCPU)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	prev := s.prev
+	s.prev = times
+	s.mu.Unlock()
+
+	// A CPU coming online or going offline changes the count; start over.
+	if prev == nil || len(prev) != len(times) {
+		return nil, false, nil
+	}
+
+	percents = make([]float64, len(times))
+	for i := range times {
+		percents[i] = cpuBusy(prev[i], times[i])
+	}
+	return percents, true, nil
+}
+
+// Percent is Sample for callers that need a reading now: without a baseline
+// it takes one and waits for wait before measuring.
+func (s *cpuSampler) Percent(wait time.Duration) ([]float64, error) {
+	percents, ok, err := s.Sample()
+	if err != nil || ok {
+		return percents, err
+	}
+
+	time.Sleep(wait)
+	percents, ok, err = s.Sample()
+	if err == nil && !ok {
+		err = errors.New("CPU count changed while sampling")
+	}
+	return percents, err
+}
+
+// cpuBusy returns the share of time between t1 and t2 the CPU wasn't idle.
+func cpuBusy(t1, t2 cpu.TimesStat) float64 {
+	all1, busy1 := cpuAllBusy(t1)
+	all2, busy2 := cpuAllBusy(t2)
+
+	if busy2 <= busy1 {
+		return 0
+	}
+	if all2 <= all1 {
+		return 100
+	}
+	return math.Min(100, math.Max(0, (busy2-busy1)/(all2-all1)*100))
+}
+
+func cpuAllBusy(t cpu.TimesStat) (all, busy float64) {
+	all = t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq +
+		t.Softirq + t.Steal + t.Guest + t.GuestNice
+	// Linux already counts guest time in user time.
+	if runtime.GOOS == "linux" {
+		all -= t.Guest + t.GuestNice
+	}
+	return all, all - t.Idle - t.Iowait
+}
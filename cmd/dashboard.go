@@ -2,20 +2,21 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
-	"os/exec"
+	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 	"github.com/dustin/go-humanize"
-	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
@@ -33,36 +34,89 @@ type viewMode int
 const (
 	dashboardView viewMode = iota
 	networkDetailView
+	errorsView
 )
 
+// staleAfter is how long the dashboard may go without a refresh before the
+// header marks its data as stale.
+const staleAfter = 5 * time.Second
+
+// partitionRefresh is how often the dashboard re-lists mounted partitions.
+// Mounts rarely change, so usage is refreshed every tick against this list.
+const partitionRefresh = 30 * time.Second
+
+// flashFor is how long the Status section stays highlighted after a check
+// changes state with --flash.
+const flashFor = 2 * time.Second
+
+var (
+	statusBell  bool
+	statusFlash bool
+)
+
+// k8sListTimeout bounds the dashboard's namespace list, so an unreachable
+// API server delays a refresh by at most this long instead of freezing it.
+// The previous namespaces stay on screen until the server answers again.
+const k8sListTimeout = 3 * time.Second
+
+// dashboardNoK8s is set by --no-k8s.
+var dashboardNoK8s bool
+
+// dashboardTick is how often the dashboard refreshes its stats.
+const dashboardTick = time.Second
+
 type focusedTable int
 
 const (
 	cpuTableFocus focusedTable = iota
 	diskTableFocus
 	netTableFocus
+	statusTableFocus
+
+	// focusableTables is the number of tables tab and shift+tab cycle through.
+	focusableTables = 4
 )
 
+// statusTableMinRows is how many checks the status table shows even when
+// the terminal is too short for the whole dashboard.
+const statusTableMinRows = 4
+
 type statusCheck struct {
 	name   string
 	status bool
+	config checkConfig
+	// checked is set once a result has come in, so the first result isn't
+	// mistaken for a change from the initial red.
+	checked bool
+	// code is the last status code of an http check.
+	code int
 }
 
 type model struct {
 	cpuPercents    []float64
+	cpuFreqs       map[int]float64
+	throttle       *throttleInfo
+	cpuSampler     *cpuSampler
 	loadAvg        *load.AvgStat
 	memory         *mem.VirtualMemoryStat
 	swap           *mem.SwapMemoryStat
 	diskStats      map[string]disk.IOCountersStat
 	diskPartitions []disk.PartitionStat
+	partitionsAt   time.Time
 	diskUsage      map[string]*disk.UsageStat
 	netStats       map[string]psnet.IOCountersStat
+	netRates       *rateTracker
+	netRxRates     map[string]string
+	netTxRates     map[string]string
+	netBaselines   map[string]psnet.IOCountersStat
 	statusChecks   []statusCheck
 	k8sClient      *kubernetes.Clientset
 	namespaces     []corev1.Namespace
+	host           string
 	width          int
 	height         int
 	lastUpdate     time.Time
+	lastStatsAt    time.Time
 	diskTable      table.Model
 	cpuTable       table.Model
 	memTable       table.Model
@@ -71,64 +125,123 @@ type model struct {
 	k8sTable       table.Model
 	focusedTable   focusedTable
 	currentView    viewMode
+	showHelp       bool
+	errors         []collectorError
+	showTimings    bool
+	refreshTook    time.Duration
+	slowest        collectorTiming
+	history        *metricHistory
+	session        *sessionStats
+	fileNote       string
+	fileErr        bool
+	flashUntil     time.Time
 	selectedIface  string
 }
 
 type tickMsg time.Time
 
-type dnsCheckMsg struct {
-	host   string
-	status bool
+type checkResultMsg checkResult
+
+// checkDueMsg is sent when the named check's interval has elapsed.
+type checkDueMsg string
+
+// collectorError records a collector that failed during a refresh, so the
+// dashboard can flag the affected section instead of leaving it blank.
+type collectorError struct {
+	section   string
+	collector string
+	err       error
 }
 
-type pingCheckMsg struct {
-	host   string
-	status bool
+// collectorTiming is how long one collector took during a refresh.
+type collectorTiming struct {
+	collector string
+	took      time.Duration
 }
 
 type statsUpdateMsg struct {
 	cpuPercents    []float64
+	cpuFreqs       []cpuFreq
+	throttle       *throttleInfo
 	loadAvg        *load.AvgStat
 	memory         *mem.VirtualMemoryStat
 	swap           *mem.SwapMemoryStat
 	diskStats      map[string]disk.IOCountersStat
 	diskPartitions []disk.PartitionStat
+	partitionsAt   time.Time
 	diskUsage      map[string]*disk.UsageStat
 	netStats       map[string]psnet.IOCountersStat
+	netSampled     time.Time
 	namespaces     []corev1.Namespace
+	errors         []collectorError
+	took           time.Duration
+	timings        []collectorTiming
 }
 
-func initialModel() model {
-	tableStyle := table.DefaultStyles()
-	tableStyle.Header = tableStyle.Header.
+// hasData reports whether any collector produced fresh data.
+func (msg statsUpdateMsg) hasData() bool {
+	return len(msg.cpuPercents) > 0 ||
+		msg.loadAvg != nil ||
+		msg.memory != nil ||
+		msg.swap != nil ||
+		len(msg.diskStats) > 0 ||
+		len(msg.diskPartitions) > 0 ||
+		len(msg.diskUsage) > 0 ||
+		len(msg.netStats) > 0 ||
+		len(msg.namespaces) > 0
+}
+
+// interactiveTableStyles are the styles of tables in the interactive
+// views, with an underlined header and the selected row in green.
+func interactiveTableStyles() table.Styles {
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderForeground(lipgloss.Color("240")).
 		BorderBottom(true).
 		Bold(true)
 
-	tableStyle.Selected = tableStyle.Selected.
+	styles.Selected = styles.Selected.
 		Foreground(lipgloss.Color("#a6d189")).
 		Bold(true)
+	return styles
+}
+
+func initialModel() model {
+	tableStyle := interactiveTableStyles()
 
 	m := model{
 		diskUsage:      make(map[string]*disk.UsageStat),
 		netStats:       make(map[string]psnet.IOCountersStat),
+		netRates:       newNetRateTracker(),
+		netRxRates:     make(map[string]string),
+		netTxRates:     make(map[string]string),
+		netBaselines:   make(map[string]psnet.IOCountersStat),
 		diskStats:      make(map[string]disk.IOCountersStat),
 		lastUpdate:     time.Now(),
+		lastStatsAt:    time.Now(),
 		cpuPercents:    make([]float64, 0),
+		cpuSampler:     newCPUSampler(true),
+		history:        newMetricHistory(historyWindow, dashboardTick),
+		session:        newSessionStats(),
 		diskPartitions: make([]disk.PartitionStat, 0),
-		statusChecks: []statusCheck{
-			{name: "runtime.uds.dev", status: false},
-			{name: "keycloak.admin.uds.dev", status: false},
-			{name: "ping 10.0.0.1", status: false},
-		},
 		focusedTable:   cpuTableFocus,
 		currentView:    dashboardView,
+		// Resolved up front, as --fqdn may wait on DNS, which View mustn't.
+		host: hostTag(),
+	}
+
+	for _, c := range configuredChecks() {
+		m.statusChecks = append(m.statusChecks, statusCheck{name: c.Name, config: c})
 	}
 
-	// Initialize k8s client
+	// Take a CPU baseline so the first tick already has usage to show.
+	m.cpuSampler.Sample()
+
+	// Initialize k8s client. Without one the Kubernetes section is left
+	// out and the network table has the bottom row to itself.
 	home := homedir.HomeDir()
-	if home != "" {
+	if home != "" && !dashboardNoK8s {
 		kubeconfig := filepath.Join(home, ".kube", "config")
 		config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 		if err == nil {
@@ -137,13 +250,13 @@ func initialModel() model {
 	}
 
 	m.diskTable = table.New(
-		table.WithColumns([]table.Column{
+		table.WithColumns(markSorted([]table.Column{
 			{Title: "Disk(d)", Width: 20},
 			{Title: "Mount(m)", Width: 20},
 			{Title: "Used(u)", Width: 15},
 			{Title: "Total", Width: 15},
 			{Title: "Used%", Width: 10},
-		}),
+		}, "Used%", true)),
 		table.WithStyles(tableStyle),
 		table.WithHeight(6),
 	)
@@ -152,6 +265,7 @@ func initialModel() model {
 		table.WithColumns([]table.Column{
 			{Title: "Core(c)", Width: 10},
 			{Title: "Usage(u)", Width: 10},
+			{Title: "MHz", Width: 6},
 		}),
 		table.WithStyles(tableStyle),
 		table.WithHeight(6),
@@ -174,6 +288,8 @@ func initialModel() model {
 			{Title: "IPv4(4)", Width: 20},
 			{Title: "RX(r)", Width: 20},
 			{Title: "TX(t)", Width: 20},
+			{Title: "RX/s", Width: 12},
+			{Title: "TX/s", Width: 12},
 		}),
 		table.WithStyles(tableStyle),
 		table.WithHeight(6),
@@ -185,14 +301,14 @@ func initialModel() model {
 			{Title: "Status", Width: 10},
 		}),
 		table.WithStyles(tableStyle),
-		table.WithHeight(4),
 	)
+	m.resizeStatusTable()
 
 	m.k8sTable = table.New(
 		table.WithColumns([]table.Column{
 			{Title: "Namespace", Width: 30},
 			{Title: "Status", Width: 10},
-			{Title: "Age", Width: 15},
+			{Title: "Age", Width: 16},
 		}),
 		table.WithStyles(tableStyle),
 		table.WithHeight(6),
@@ -202,153 +318,191 @@ func initialModel() model {
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(
-		tickCmd(),
-		checkDNSCmd("runtime.uds.dev"),
-		checkDNSCmd("keycloak.admin.uds.dev"),
-		checkPingCmd("10.0.0.1"),
-	)
+	return tea.Batch(tickCmd(), checksCmd())
 }
 
 func tickCmd() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(dashboardTick, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
-func checkDNSCmd(host string) tea.Cmd {
-	return func() tea.Msg {
-		_, err := net.LookupHost(host)
-		return dnsCheckMsg{host: host, status: err == nil}
+// checksCmd runs every configured status check once at startup. After that
+// each check schedules itself on its own interval, so a slow check never
+// holds up the others or the stats tick.
+func checksCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, c := range configuredChecks() {
+		cmds = append(cmds, checkCmd(c))
 	}
+	return tea.Batch(cmds...)
 }
 
-func checkPingCmd(host string) tea.Cmd {
+func checkCmd(c checkConfig) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("ping", "-c", "1", "-W", "1", host)
-		return pingCheckMsg{host: host, status: cmd.Run() == nil}
+		return checkResultMsg(runCheck(context.Background(), c))
 	}
 }
 
+// scheduleCheck sends a checkDueMsg for the named check once its interval
+// has passed since the last result.
+func scheduleCheck(c checkConfig) tea.Cmd {
+	return tea.Tick(c.Interval, func(time.Time) tea.Msg {
+		return checkDueMsg(c.Name)
+	})
+}
+
 func (m *model) updateStats() tea.Cmd {
+	// Captured now because the command runs after Update has returned.
+	partitions, partitionsAt := m.diskPartitions, m.partitionsAt
+	k8sClient := m.k8sClient
+	sampler := m.cpuSampler
+
 	return func() tea.Msg {
-		var wg sync.WaitGroup
-		var mu sync.Mutex
 		msg := statsUpdateMsg{
 			diskUsage: make(map[string]*disk.UsageStat),
 			diskStats: make(map[string]disk.IOCountersStat),
 			netStats:  make(map[string]psnet.IOCountersStat),
 		}
 
-		// CPU stats
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if percents, err := cpu.Percent(0, true); err == nil {
-				mu.Lock()
+		start := time.Now()
+		fail := func(section, collector string, err error) {
+			msg.errors = append(msg.errors, collectorError{section, collector, err})
+		}
+		measure := func(collector string, collect func()) {
+			began, failed := time.Now(), len(msg.errors)
+			collect()
+			took := time.Since(began)
+			msg.timings = append(msg.timings, collectorTiming{collector, took})
+
+			var err error
+			if len(msg.errors) > failed {
+				err = msg.errors[failed].err
+			}
+			health.Collected(collector, took, err)
+		}
+
+		// The Kubernetes API is the only collector that waits on the
+		// network, so it runs alongside the rest. The local collectors are
+		// quick reads of /proc and /sys and run in turn, which saves a
+		// goroutine and lock round trip per collector on every tick.
+		type namespaceList struct {
+			items []corev1.Namespace
+			err   error
+			took  time.Duration
+		}
+		var namespaces chan namespaceList
+		if k8sClient != nil {
+			namespaces = make(chan namespaceList, 1)
+			go func() {
+				began := time.Now()
+				ctx, cancel := context.WithTimeout(context.Background(), k8sListTimeout)
+				defer cancel()
+				list, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+				if errors.Is(err, context.DeadlineExceeded) {
+					err = fmt.Errorf("API server didn't answer within %s", k8sListTimeout)
+				}
+				if err != nil {
+					namespaces <- namespaceList{err: err, took: time.Since(began)}
+					return
+				}
+				namespaces <- namespaceList{items: list.Items, took: time.Since(began)}
+			}()
+		}
+
+		// Never cpu.Percent with an interval here: it sleeps, stalling the
+		// whole tick. The sampler measures since the previous tick instead.
+		measure("cpu usage", func() {
+			if percents, ok, err := sampler.Sample(); err != nil {
+				fail("cpu", "cpu usage", err)
+			} else if ok {
 				msg.cpuPercents = percents
-				mu.Unlock()
 			}
-		}()
-
-		// Load average
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if loadAvg, err := load.Avg(); err == nil {
-				mu.Lock()
+		})
+		measure("cpu frequency", func() {
+			if freqs, err := readCPUFreqs(); err != nil {
+				fail("cpu", "cpu frequency", err)
+			} else {
+				msg.cpuFreqs = freqs
+			}
+		})
+		if len(msg.cpuFreqs) > 0 {
+			measure("throttling", func() {
+				if throttle, err := throttlingFrom(msg.cpuFreqs); err != nil {
+					fail("cpu", "temperature sensors", err)
+				} else {
+					msg.throttle = throttle
+				}
+			})
+		}
+		measure("load average", func() {
+			if loadAvg, err := load.Avg(); err != nil {
+				fail("cpu", "load average", err)
+			} else {
 				msg.loadAvg = loadAvg
-				mu.Unlock()
 			}
-		}()
-
-		// Memory stats
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if vmem, err := mem.VirtualMemory(); err == nil {
-				mu.Lock()
+		})
+		measure("memory", func() {
+			if vmem, err := mem.VirtualMemory(); err != nil {
+				fail("memory", "memory", err)
+			} else {
 				msg.memory = vmem
-				mu.Unlock()
 			}
-		}()
-
-		// Swap stats
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if swap, err := mem.SwapMemory(); err == nil {
-				mu.Lock()
+			if swap, err := mem.SwapMemory(); err != nil {
+				fail("memory", "swap", err)
+			} else {
 				msg.swap = swap
-				mu.Unlock()
 			}
-		}()
-
-		// Disk IO stats
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if iostats, err := disk.IOCounters(); err == nil {
-				mu.Lock()
+		})
+		measure("disk io", func() {
+			if iostats, err := disk.IOCounters(); err != nil {
+				fail("disks", "disk io", err)
+			} else {
 				msg.diskStats = iostats
-				mu.Unlock()
 			}
-		}()
-
-		// Disk partitions and usage
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if partitions, err := disk.Partitions(false); err == nil {
-				mu.Lock()
-				msg.diskPartitions = partitions
-				mu.Unlock()
-
-				var usageWg sync.WaitGroup
-				for _, partition := range partitions {
-					usageWg.Add(1)
-					go func(p disk.PartitionStat) {
-						defer usageWg.Done()
-						if usage, err := disk.Usage(p.Mountpoint); err == nil {
-							mu.Lock()
-							msg.diskUsage[p.Mountpoint] = usage
-							mu.Unlock()
-						}
-					}(partition)
+		})
+
+		measure("disk usage", func() {
+			if len(partitions) == 0 || time.Since(partitionsAt) >= partitionRefresh {
+				if fresh, err := disk.Partitions(false); err != nil {
+					fail("disks", "partitions", err)
+				} else {
+					partitions, partitionsAt = filterPartitions(fresh), time.Now()
 				}
-				usageWg.Wait()
 			}
-		}()
-
-		// Network stats
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if iostats, err := psnet.IOCounters(false); err == nil {
-				netStats := make(map[string]psnet.IOCountersStat)
-				for _, stat := range iostats {
-					netStats[stat.Name] = stat
+			msg.diskPartitions = partitions
+			msg.partitionsAt = partitionsAt
+			for _, partition := range partitions {
+				if usage, err := disk.Usage(partition.Mountpoint); err != nil {
+					fail("disks", "usage of "+partition.Mountpoint, err)
+				} else {
+					msg.diskUsage[partition.Mountpoint] = usage
 				}
-				mu.Lock()
-				msg.netStats = netStats
-				mu.Unlock()
 			}
-		}()
+		})
 
-		// K8s stats
-		if m.k8sClient != nil {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				if namespaces, err := m.k8sClient.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{}); err == nil {
-					mu.Lock()
-					msg.namespaces = namespaces.Items
-					mu.Unlock()
+		measure("network", func() {
+			if iostats, err := psnet.IOCounters(true); err != nil {
+				fail("network", "interface counters", err)
+			} else {
+				for _, stat := range iostats {
+					msg.netStats[stat.Name] = stat
 				}
-			}()
+				msg.netSampled = time.Now()
+			}
+		})
+
+		if namespaces != nil {
+			list := <-namespaces
+			if list.err != nil {
+				fail("kubernetes", "namespaces", list.err)
+			}
+			msg.namespaces = list.items
+			msg.timings = append(msg.timings, collectorTiming{"kubernetes", list.took})
+			health.Collected("kubernetes", list.took, list.err)
 		}
 
-		wg.Wait()
+		msg.took = time.Since(start)
 		return msg
 	}
 }
@@ -356,14 +510,43 @@ func (m *model) updateStats() tea.Cmd {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.showHelp {
+			// The help overlay swallows everything except quitting and
+			// closing it again.
+			switch msg.String() {
+			case "q", "ctrl+c":
+				return m, tea.Quit
+			case "?", "esc":
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "?":
+			m.showHelp = true
+			return m, nil
+		case "w":
+			return m, writeHistoryCmd(m.history.Snapshot())
+		case "y":
+			return m, writeSnapshotCmd(m.snapshot())
+		case "r":
+			m.session.Reset()
+			return m, nil
+		case "e":
+			if m.currentView == dashboardView {
+				m.currentView = errorsView
+			}
+			return m, nil
 		case "esc":
-			if m.currentView == networkDetailView {
+			// esc backs out one level, so on the main dashboard it quits.
+			if m.currentView != dashboardView {
 				m.currentView = dashboardView
 				return m, nil
 			}
+			return m, tea.Quit
 		case "enter":
 			if m.focusedTable == netTableFocus && m.currentView == dashboardView {
 				selectedRow := m.netTable.SelectedRow()
@@ -375,25 +558,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "tab":
 			if m.currentView == dashboardView {
-				m.focusedTable = (m.focusedTable + 1) % 3
-
-				switch m.focusedTable {
-				case cpuTableFocus:
-					m.cpuTable.Focus()
-					m.diskTable.Blur()
-					m.netTable.Blur()
-				case diskTableFocus:
-					m.diskTable.Focus()
-					m.cpuTable.Blur()
-					m.netTable.Blur()
-				case netTableFocus:
-					m.netTable.Focus()
-					m.cpuTable.Blur()
-					m.diskTable.Blur()
-				}
+				m.setFocus((m.focusedTable + 1) % focusableTables)
+			}
+			return m, nil
+		case "shift+tab":
+			if m.currentView == dashboardView {
+				m.setFocus((m.focusedTable + focusableTables - 1) % focusableTables)
 			}
 			return m, nil
-		case "up", "down", "pageup", "pagedown", "home", "end":
+		case "up", "down", "pgup", "pgdown", "home", "end",
+			"k", "j", "ctrl+u", "ctrl+d", "g", "G":
+			// The table's default key map already understands the vim
+			// bindings, so they're forwarded unchanged like the arrows.
 			if m.currentView == dashboardView {
 				var cmd tea.Cmd
 				switch m.focusedTable {
@@ -403,6 +579,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.diskTable, cmd = m.diskTable.Update(msg)
 				case netTableFocus:
 					m.netTable, cmd = m.netTable.Update(msg)
+				case statusTableFocus:
+					m.statusTable, cmd = m.statusTable.Update(msg)
 				}
 				return m, cmd
 			}
@@ -411,41 +589,59 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeStatusTable()
 
 	case tickMsg:
 		m.lastUpdate = time.Time(msg)
 		return m, tea.Batch(
 			m.updateStats(),
 			tickCmd(),
-			checkDNSCmd("runtime.uds.dev"),
-			checkDNSCmd("keycloak.admin.uds.dev"),
-			checkPingCmd("10.0.0.1"),
 		)
 
-	case dnsCheckMsg:
-		for i := range m.statusChecks {
-			if m.statusChecks[i].name == msg.host {
-				m.statusChecks[i].status = msg.status
-				break
+	case checkResultMsg:
+		m.session.Observe(msg.Name+" latency", msg.LatencyMs, formatMs)
+		cmd := m.setCheckStatus(msg.Name, msg.OK)
+		for i, check := range m.statusChecks {
+			if check.name == msg.Name {
+				m.statusChecks[i].code = msg.Code
+				m.updateTables()
+				return m, tea.Batch(cmd, scheduleCheck(check.config))
 			}
 		}
 		m.updateTables()
+		return m, cmd
 
-	case pingCheckMsg:
-		for i := range m.statusChecks {
-			if m.statusChecks[i].name == "ping "+msg.host {
-				m.statusChecks[i].status = msg.status
-				break
+	case checkDueMsg:
+		for _, check := range m.statusChecks {
+			if check.name == string(msg) {
+				return m, checkCmd(check.config)
 			}
 		}
-		m.updateTables()
+
+	case fileWrittenMsg:
+		m.fileErr = msg.err != nil
+		if msg.err != nil {
+			m.fileNote = msg.err.Error()
+		} else {
+			m.fileNote = "wrote " + msg.path
+		}
 
 	case statsUpdateMsg:
+		m.recordHistory(msg)
+		m.observeSession(msg)
 		if len(msg.cpuPercents) > 0 {
 			m.cpuPercents = msg.cpuPercents
 		}
+		m.throttle = msg.throttle
+		if len(msg.cpuFreqs) > 0 {
+			m.cpuFreqs = make(map[int]float64, len(msg.cpuFreqs))
+			for _, f := range msg.cpuFreqs {
+				m.cpuFreqs[f.CPU] = f.CurMHz
+			}
+		}
 		if msg.loadAvg != nil {
 			m.loadAvg = msg.loadAvg
+			alerts.Threshold("load1", "load1", msg.loadAvg.Load1, cfg.Thresholds.Load1)
 		}
 		if msg.memory != nil {
 			m.memory = msg.memory
@@ -458,16 +654,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if len(msg.diskPartitions) > 0 {
 			m.diskPartitions = msg.diskPartitions
+			m.partitionsAt = msg.partitionsAt
 		}
 		if len(msg.diskUsage) > 0 {
 			m.diskUsage = msg.diskUsage
+			for mount, usage := range msg.diskUsage {
+				alerts.Threshold("disk:"+mount, "disk_percent", usage.UsedPercent, cfg.Thresholds.DiskPercent)
+			}
 		}
 		if len(msg.netStats) > 0 {
+			m.updateNetBaselines(msg.netStats)
 			m.netStats = msg.netStats
+			for name, stat := range msg.netStats {
+				m.netRxRates[name] = m.netRates.Format(name+"/rx", stat.BytesRecv, msg.netSampled)
+				m.netTxRates[name] = m.netRates.Format(name+"/tx", stat.BytesSent, msg.netSampled)
+			}
 		}
 		if len(msg.namespaces) > 0 {
 			m.namespaces = msg.namespaces
 		}
+		if msg.hasData() {
+			m.lastStatsAt = time.Now()
+		}
+		m.errors = msg.errors
+		m.refreshTook = msg.took
+		m.slowest = collectorTiming{}
+		for _, t := range msg.timings {
+			if t.took > m.slowest.took {
+				m.slowest = t
+			}
+		}
 		m.updateTables()
 		return m, nil
 	}
@@ -475,12 +691,128 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// setCheckStatus records the result of the named status check. When the
+// check changes state it flashes the Status section with --flash and returns
+// a command ringing the terminal bell with --bell.
+func (m *model) setCheckStatus(name string, status bool) tea.Cmd {
+	for i := range m.statusChecks {
+		check := &m.statusChecks[i]
+		if check.name != name {
+			continue
+		}
+		changed := check.checked && check.status != status
+		check.status = status
+		check.checked = true
+		alerts.Check(check.name, status)
+
+		if !changed {
+			return nil
+		}
+		if statusFlash {
+			m.flashUntil = time.Now().Add(flashFor)
+		}
+		if statusBell {
+			return ringBell
+		}
+		return nil
+	}
+	return nil
+}
+
+// ringBell writes BEL to stderr, which reaches the terminal without going
+// through the renderer and disturbing the layout.
+func ringBell() tea.Msg {
+	_, _ = os.Stderr.WriteString("\a")
+	return nil
+}
+
+// recordHistory appends the overall CPU, memory and network figures from msg
+// to the history "w" writes out.
+func (m *model) recordHistory(msg statsUpdateMsg) {
+	now := time.Now()
+	if len(msg.cpuPercents) > 0 {
+		var total float64
+		for _, percent := range msg.cpuPercents {
+			total += percent
+		}
+		m.history.Record("cpu_percent", now, total/float64(len(msg.cpuPercents)))
+	}
+	if msg.memory != nil {
+		m.history.Record("mem_percent", now, msg.memory.UsedPercent)
+	}
+	if msg.swap != nil {
+		m.history.Record("swap_percent", now, msg.swap.UsedPercent)
+	}
+	if len(msg.netStats) > 0 {
+		var rx, tx uint64
+		for _, stat := range msg.netStats {
+			rx += stat.BytesRecv
+			tx += stat.BytesSent
+		}
+		m.history.RecordNet(rx, tx, msg.netSampled)
+	}
+}
+
+// setFocus moves keyboard focus to table t and blurs the others.
+func (m *model) setFocus(t focusedTable) {
+	m.focusedTable = t
+
+	m.cpuTable.Blur()
+	m.diskTable.Blur()
+	m.netTable.Blur()
+	m.statusTable.Blur()
+	switch t {
+	case cpuTableFocus:
+		m.cpuTable.Focus()
+	case diskTableFocus:
+		m.diskTable.Focus()
+	case netTableFocus:
+		m.netTable.Focus()
+	case statusTableFocus:
+		m.statusTable.Focus()
+	}
+}
+
+// resizeStatusTable gives the status table a row per check, as far as the
+// terminal has room for them below the other sections, and never fewer than
+// statusTableMinRows. With more checks than that, the table scrolls when
+// focused.
+func (m *model) resizeStatusTable() {
+	rows := max(1, len(m.statusChecks))
+	if m.height > 0 {
+		m.statusTable.SetHeight(1)
+		spare := m.height - lipgloss.Height(m.dashboardLayout())
+		rows = max(min(rows, statusTableMinRows), min(rows, 1+spare))
+	}
+	m.statusTable.SetHeight(rows)
+}
+
+// updateNetBaselines records the first sample seen for each interface so the
+// detail view can show bytes transferred this session. The baseline is reset
+// when the counters go backwards or the interface disappears and comes back.
+func (m *model) updateNetBaselines(stats map[string]psnet.IOCountersStat) {
+	for name, stat := range stats {
+		base, ok := m.netBaselines[name]
+		_, present := m.netStats[name]
+		if !ok || (!present && len(m.netStats) > 0) ||
+			stat.BytesRecv < base.BytesRecv || stat.BytesSent < base.BytesSent {
+			m.netBaselines[name] = stat
+		}
+	}
+}
+
 func (m *model) updateTables() {
 	var cpuRows []table.Row
 	for i, percent := range m.cpuPercents {
+		// Without cpufreq (most VMs) there's no frequency to show.
+		freq := "-"
+		if mhz, ok := m.cpuFreqs[i]; ok {
+			freq = fmt.Sprintf("%.0f", mhz)
+		}
 		cpuRows = append(cpuRows, table.Row{
 			fmt.Sprintf("%d", i),
 			fmt.Sprintf("%.1f%%", percent),
+			freq,
 		})
 	}
 	m.cpuTable.SetRows(cpuRows)
@@ -517,13 +849,16 @@ func (m *model) updateTables() {
 			})
 		}
 	}
-	sort.Slice(diskRows, func(i, j int) bool {
+	sort.SliceStable(diskRows, func(i, j int) bool {
 		iPercent := strings.TrimSuffix(diskRows[i][4], "%")
 		jPercent := strings.TrimSuffix(diskRows[j][4], "%")
 		var iVal, jVal float64
 		fmt.Sscanf(iPercent, "%f", &iVal)
 		fmt.Sscanf(jPercent, "%f", &jVal)
-		return iVal > jVal
+		if iVal != jVal {
+			return iVal > jVal
+		}
+		return diskRows[i][1] < diskRows[j][1]
 	})
 	m.diskTable.SetRows(diskRows)
 
@@ -543,6 +878,8 @@ func (m *model) updateTables() {
 				strings.Join(ipv4s, ", "),
 				humanize.Bytes(uint64(stats.BytesRecv)),
 				humanize.Bytes(uint64(stats.BytesSent)),
+				m.netRxRates[iface.Name],
+				m.netTxRates[iface.Name],
 			})
 		}
 	}
@@ -550,12 +887,19 @@ func (m *model) updateTables() {
 
 	var statusRows []table.Row
 	for _, check := range m.statusChecks {
+		status := getStatusSymbol(check.status)
+		if check.code != 0 {
+			status += " " + strconv.Itoa(check.code)
+		}
 		statusRows = append(statusRows, table.Row{
 			check.name,
-			getStatusSymbol(check.status),
+			status,
 		})
 	}
 	m.statusTable.SetRows(statusRows)
+	// Other sections grow and shrink with what's collected, e.g. the
+	// memory table once swap is seen.
+	m.resizeStatusTable()
 
 	if m.k8sClient != nil {
 		var k8sRows []table.Row
@@ -567,7 +911,7 @@ func (m *model) updateTables() {
 			k8sRows = append(k8sRows, table.Row{
 				ns.Name,
 				status,
-				humanize.Time(ns.CreationTimestamp.Time),
+				formatAge(ns.CreationTimestamp.Time, time.Now()),
 			})
 		}
 		m.k8sTable.SetRows(k8sRows)
@@ -586,10 +930,27 @@ func (m model) View() string {
 		return "Loading..."
 	}
 
+	if m.showHelp {
+		return m.helpView()
+	}
+
 	if m.currentView == networkDetailView {
 		return m.networkDetailView()
 	}
 
+	if m.currentView == errorsView {
+		return m.errorsView()
+	}
+
+	return lipgloss.NewStyle().
+		MaxWidth(m.width).
+		MaxHeight(m.height).
+		Render(m.dashboardLayout())
+}
+
+// dashboardLayout renders the main dashboard's sections at their full
+// height, for View to crop to the terminal.
+func (m model) dashboardLayout() string {
 	availWidth := m.width
 	minColumnWidth := 85
 	useVerticalLayout := availWidth < minColumnWidth*2
@@ -609,11 +970,16 @@ func (m model) View() string {
 		Foreground(lipgloss.Color("#8caaee")).
 		Bold(true)
 
-	// Status section at the top
-	statusSection := style.Copy().Width(availWidth - 2).Render(
+	// Status section at the top, outlined in red for a moment after a
+	// check changes with --flash.
+	statusStyle := style.Copy().Width(availWidth - 2)
+	if time.Now().Before(m.flashUntil) {
+		statusStyle = statusStyle.BorderForeground(lipgloss.Color("#e78284"))
+	}
+	statusSection := statusStyle.Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
-			headerStyle.Render("Status"),
+			headerStyle.Render(fmt.Sprintf("Status %s", m.getFocusIndicator(statusTableFocus)))+"  "+m.host+m.throttleIndicator()+"  "+m.updatedIndicator()+m.timingIndicator()+m.fileIndicator()+"  (? for help)",
 			m.statusTable.View(),
 		),
 	)
@@ -623,7 +989,7 @@ func (m model) View() string {
 		cpuSection = style.Copy().Width(availWidth/3 - 2).Render(
 			lipgloss.JoinVertical(
 				lipgloss.Left,
-				headerStyle.Render(fmt.Sprintf("CPU %s", m.getFocusIndicator(cpuTableFocus))),
+				headerStyle.Render(fmt.Sprintf("CPU %s", m.getFocusIndicator(cpuTableFocus)))+m.errorIndicator("cpu"),
 				m.cpuTable.View(),
 				"",
 				"",
@@ -638,7 +1004,7 @@ func (m model) View() string {
 		cpuSection = style.Copy().Width(availWidth/3 - 2).Render(
 			lipgloss.JoinVertical(
 				lipgloss.Left,
-				headerStyle.Render(fmt.Sprintf("CPU %s", m.getFocusIndicator(cpuTableFocus))),
+				headerStyle.Render(fmt.Sprintf("CPU %s", m.getFocusIndicator(cpuTableFocus)))+m.errorIndicator("cpu"),
 				m.cpuTable.View(),
 				"",
 				"",
@@ -651,7 +1017,7 @@ func (m model) View() string {
 	diskSection := style.Copy().Width(2*availWidth/3 - 2).Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
-			headerStyle.Render(fmt.Sprintf("Disks %s", m.getFocusIndicator(diskTableFocus))),
+			headerStyle.Render(fmt.Sprintf("Disks %s", m.getFocusIndicator(diskTableFocus)))+m.errorIndicator("disks"),
 			m.diskTable.View(),
 		),
 	)
@@ -659,7 +1025,7 @@ func (m model) View() string {
 	memSection := style.Copy().Width(2*availWidth/3 - 2).Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
-			headerStyle.Render("Memory"),
+			headerStyle.Render("Memory")+m.errorIndicator("memory"),
 			m.memTable.View(),
 		),
 	)
@@ -670,7 +1036,7 @@ func (m model) View() string {
 	netSection := style.Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
-			headerStyle.Render(fmt.Sprintf("Network %s", m.getFocusIndicator(netTableFocus))),
+			headerStyle.Render(fmt.Sprintf("Network %s", m.getFocusIndicator(netTableFocus)))+m.errorIndicator("network"),
 			m.netTable.View(),
 		),
 	)
@@ -680,7 +1046,7 @@ func (m model) View() string {
 		k8sSection = style.Render(
 			lipgloss.JoinVertical(
 				lipgloss.Left,
-				headerStyle.Render("Kubernetes"),
+				headerStyle.Render("Kubernetes")+m.errorIndicator("kubernetes"),
 				m.k8sTable.View(),
 			),
 		)
@@ -691,12 +1057,41 @@ func (m model) View() string {
 		statusSection,
 		topRow,
 		bottomRow,
+		" "+m.session.Line()+"  (r to reset)",
 	)
+	return finalLayout
+}
 
-	return lipgloss.NewStyle().
-		MaxWidth(m.width).
-		MaxHeight(m.height).
-		Render(finalLayout)
+// observeSession adds a stats update to the session's min/avg/max of CPU,
+// load and memory. CPU is the mean across cores, as in the history.
+func (m *model) observeSession(msg statsUpdateMsg) {
+	if len(msg.cpuPercents) > 0 {
+		var sum float64
+		for _, p := range msg.cpuPercents {
+			sum += p
+		}
+		m.session.Observe("CPU", sum/float64(len(msg.cpuPercents)), formatPercent)
+	}
+	if msg.loadAvg != nil {
+		m.session.Observe("Load 1m", msg.loadAvg.Load1, formatLoad)
+	}
+	if msg.memory != nil {
+		m.session.Observe("Memory", msg.memory.UsedPercent, formatPercent)
+	}
+}
+
+// updatedIndicator reports how long ago the collectors last produced fresh
+// data, in red once that exceeds staleAfter. This is tracked separately from
+// lastUpdate, which advances on every tick even when collection has stalled.
+func (m model) updatedIndicator() string {
+	age := time.Since(m.lastStatsAt).Truncate(time.Second)
+	indicator := fmt.Sprintf("updated %s ago", age)
+	if age > staleAfter {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#e78284")).
+			Render(indicator + " (stale)")
+	}
+	return indicator
 }
 
 func (m model) networkDetailView() string {
@@ -724,9 +1119,18 @@ func (m model) networkDetailView() string {
 			fmt.Sprintf("TX Errors:    %d", stats.Errout),
 			fmt.Sprintf("TX Dropped:   %d", stats.Dropout),
 			"",
-			"Press ESC to return",
 		}
 
+		if base, ok := m.netBaselines[m.selectedIface]; ok {
+			content = append(content,
+				fmt.Sprintf("RX Session:   %s", humanize.Bytes(stats.BytesRecv-base.BytesRecv)),
+				fmt.Sprintf("TX Session:   %s", humanize.Bytes(stats.BytesSent-base.BytesSent)),
+				"",
+			)
+		}
+
+		content = append(content, "Press ESC to return")
+
 		return style.Render(lipgloss.JoinVertical(
 			lipgloss.Left,
 			content...,
@@ -736,6 +1140,135 @@ func (m model) networkDetailView() string {
 	return "Interface not found"
 }
 
+// keyHelp is a line of a help overlay.
+type keyHelp struct {
+	keys, action string
+}
+
+// dashboardKeys are the key bindings listed in the help overlay.
+var dashboardKeys = []keyHelp{
+	{"tab / shift+tab", "focus next / previous table"},
+	{"up / down, k / j", "move selection in the focused table"},
+	{"pgup / pgdown", "page through the focused table"},
+	{"ctrl+u / ctrl+d", "move half a page up / down"},
+	{"home / end, g / G", "jump to the first / last row"},
+	{"enter", "open details for the selected interface"},
+	{"e", "show collector errors (sections marked !)"},
+	{"w", "write the last --history of samples to a file"},
+	{"y", "write everything on screen to a JSON file, for bug reports"},
+	{"r", "reset the session min/avg/max at the bottom"},
+	{"esc", "go back, or quit from the dashboard"},
+	{"?", "toggle this help"},
+	{"q / ctrl+c", "quit"},
+}
+
+func (m model) helpView() string {
+	return helpOverlay(dashboardKeys)
+}
+
+// helpOverlay renders key bindings in a box, for the "?" overlay of the
+// interactive views.
+func helpOverlay(keys []keyHelp) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7287fd")).
+		Padding(1, 2)
+
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8caaee")).
+		Bold(true)
+
+	content := []string{headerStyle.Render("Keys"), ""}
+	for _, k := range keys {
+		content = append(content, fmt.Sprintf("%-20s %s", k.keys, k.action))
+	}
+	content = append(content, "", "Press ? or ESC to close")
+
+	return style.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		content...,
+	))
+}
+
+// timingIndicator shows how long the last refresh took and its slowest
+// collector when running with --level debug or --verbose.
+func (m model) timingIndicator() string {
+	if !m.showTimings || m.refreshTook == 0 {
+		return ""
+	}
+	return fmt.Sprintf("  refresh took %s (slowest: %s %s)",
+		m.refreshTook.Round(time.Microsecond),
+		m.slowest.collector,
+		m.slowest.took.Round(time.Microsecond),
+	)
+}
+
+// fileIndicator reports where "w" or "y" last wrote a file, or why it
+// couldn't.
+func (m model) fileIndicator() string {
+	if m.fileNote == "" {
+		return ""
+	}
+	if m.fileErr {
+		return "  " + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#e78284")).
+			Render(m.fileNote)
+	}
+	return "  " + m.fileNote
+}
+
+// throttleIndicator warns in the Status header while the CPU is thermally
+// throttling, since that's easy to miss and explains a lot.
+func (m model) throttleIndicator() string {
+	if m.throttle == nil || !m.throttle.Throttling {
+		return ""
+	}
+	return "  " + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e78284")).
+		Bold(true).
+		Render(fmt.Sprintf("THROTTLING (%s)", formatTemp(m.throttle.CelsiusCurrent)))
+}
+
+// errorIndicator marks a section header with a red "!" while any of its
+// collectors failed on the last refresh. The details are under "e".
+func (m model) errorIndicator(section string) string {
+	for _, e := range m.errors {
+		if e.section == section {
+			return " " + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#e78284")).
+				Bold(true).
+				Render("!")
+		}
+	}
+	return ""
+}
+
+func (m model) errorsView() string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7287fd")).
+		Padding(1, 2).
+		Width(m.width - 4)
+
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8caaee")).
+		Bold(true)
+
+	content := []string{headerStyle.Render("Collector Errors"), ""}
+	if len(m.errors) == 0 {
+		content = append(content, "No errors on the last refresh")
+	}
+	for _, e := range m.errors {
+		content = append(content, fmt.Sprintf("%-10s %s: %v", e.section, e.collector, e.err))
+	}
+	content = append(content, "", "Press ESC to return")
+
+	return style.Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		content...,
+	))
+}
+
 func (m model) getFocusIndicator(t focusedTable) string {
 	if m.focusedTable == t {
 		return "●"
@@ -747,16 +1280,41 @@ var dashboardCmd = &cobra.Command{
 	Use:     "dashboard",
 	Aliases: []string{"dash"},
 	Short:   "Interactive system dashboard",
-	Run: func(cmd *cobra.Command, args []string) {
-		p := tea.NewProgram(initialModel(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if historyWindow < dashboardTick {
+			return fmt.Errorf("--history must be at least %s, got %s", dashboardTick, historyWindow)
+		}
+		if historyFormat != "csv" && historyFormat != "json" {
+			return fmt.Errorf("invalid --history-format %q: must be one of csv, json", historyFormat)
+		}
+		if !cmd.Flags().Changed("bell") {
+			statusBell = cfg.Dashboard.Bell
+		}
+		if !cmd.Flags().Changed("flash") {
+			statusFlash = cfg.Dashboard.Flash
+		}
+
+		// Timings go in the Status header rather than the log, which
+		// would draw over the alt screen.
+		health.Expect(dashboardTick)
+		m := initialModel()
+		m.showTimings = log.FromContext(cmd.Context()).GetLevel() <= log.DebugLevel
+		p := tea.NewProgram(m,
 			tea.WithAltScreen(),
 			tea.WithMouseCellMotion())
 		if _, err := p.Run(); err != nil {
 			fmt.Printf("Error running program: %v\n", err)
 		}
+		return nil
 	},
 }
 
 func init() {
+	dashboardCmd.Flags().DurationVar(&historyWindow, "history", 10*time.Minute, "how much history to keep for writing out with \"w\"")
+	dashboardCmd.Flags().BoolVar(&dashboardNoK8s, "no-k8s", false, "leave out the Kubernetes section, even when a kubeconfig exists")
+	dashboardCmd.Flags().StringSliceVar(&diskMounts, "mount", nil, "only show filesystems mounted at these paths in the Disks table (e.g. /,/var)")
+	dashboardCmd.Flags().BoolVar(&statusBell, "bell", false, "ring the terminal bell when a status check changes state")
+	dashboardCmd.Flags().BoolVar(&statusFlash, "flash", false, "briefly outline the Status section in red when a status check changes state")
+	dashboardCmd.Flags().StringVar(&historyFormat, "history-format", "csv", "file format \"w\" writes history in (csv, json)")
 	rootCmd.AddCommand(dashboardCmd)
 }
@@ -0,0 +1,16 @@
+package cmd
+
+import "testing"
+
+// BenchmarkUpdateStats measures one dashboard tick's collection, with the
+// partition list carried over between ticks as the dashboard does.
+func BenchmarkUpdateStats(b *testing.B) {
+	m := initialModel()
+	// The Kubernetes API would make the numbers about the network.
+	m.k8sClient = nil
+	b.ReportAllocs()
+	for range b.N {
+		msg := m.updateStats()().(statsUpdateMsg)
+		m.diskPartitions, m.partitionsAt = msg.diskPartitions, msg.partitionsAt
+	}
+}
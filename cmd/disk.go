@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
@@ -11,6 +14,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	diskSort           string
+	diskExtended       bool
+	diskDevices        []string
+	diskExcludeDevices []string
+	diskMounts         []string
+)
+
 var diskCmd = &cobra.Command{
 	Use:   "disk",
 	Short: "Display disk usage and IO statistics",
@@ -18,36 +29,47 @@ var diskCmd = &cobra.Command{
 Provides information about:
   - Partitions and mount points
   - Disk usage statistics
-  - IO counters and statistics`,
+  - IO counters and statistics
+
+See "systat disk smart" for the health of the disks themselves.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := log.FromContext(cmd.Context())
+		rates := newRateTracker()
+		fill := newFillTracker()
 
-		for {
-			if err := showDiskInfo(logger); err != nil {
-				return err
-			}
-
-			if !watchOutput {
-				break
-			}
-			time.Sleep(2 * time.Second)
-			fmt.Print("\033[H\033[2J") // Clear screen in watch mode
+		switch diskSort {
+		case "name", "read", "write":
+		default:
+			return fmt.Errorf("invalid --sort %q: must be one of name, read, write", diskSort)
 		}
-		return nil
+
+		return runWatch(cmd.Context(), func() error {
+			return showDiskInfo(logger, rates, fill)
+		})
 	},
 }
 
-func showDiskInfo(logger *log.Logger) error {
+func showDiskInfo(logger *log.Logger, rates *rateTracker, fill *fillTracker) error {
 	logger.Debug("gathering disk information")
+	defer timeCollector(logger, "disk")()
 
-	if rawOutput {
-		return showRawDiskInfo()
+	if structuredOutput() {
+		return showJSONDiskInfo()
+	}
+
+	if pointOutput() {
+		info, err := collectDisk()
+		if err != nil {
+			return err
+		}
+		return emitPoints(logger, diskPoints(info, time.Now()))
 	}
 
 	partitions, err := disk.Partitions(false)
 	if err != nil {
 		return fmt.Errorf("failed to get disk partitions: %w", err)
 	}
+	partitions = filterPartitions(partitions)
 
 	fmt.Println(titleStyle.Render("Disk Partitions"))
 	columns := []table.Column{
@@ -59,6 +81,9 @@ func showDiskInfo(logger *log.Logger) error {
 		{Title: "Free", Width: 10},
 		{Title: "Use%", Width: 8},
 	}
+	if watchOutput {
+		columns = append(columns, table.Column{Title: "Full In", Width: 10})
+	}
 
 	var rows []table.Row
 	for _, partition := range partitions {
@@ -66,8 +91,10 @@ func showDiskInfo(logger *log.Logger) error {
 		if err != nil {
 			continue
 		}
+		alerts.Threshold("disk:"+partition.Mountpoint, "disk_percent", usage.UsedPercent, cfg.Thresholds.DiskPercent)
+		watchStats.Observe(partition.Mountpoint+" Used%", usage.UsedPercent, formatPercent)
 
-		rows = append(rows, table.Row{
+		row := table.Row{
 			partition.Device,
 			partition.Mountpoint,
 			partition.Fstype,
@@ -75,7 +102,11 @@ func showDiskInfo(logger *log.Logger) error {
 			humanize.Bytes(usage.Used),
 			humanize.Bytes(usage.Free),
 			fmt.Sprintf("%.1f%%", usage.UsedPercent),
-		})
+		}
+		if watchOutput {
+			row = append(row, fill.Estimate(partition.Mountpoint, usage, time.Now()))
+		}
+		rows = append(rows, row)
 	}
 
 	t := NewTable(columns, rows)
@@ -85,29 +116,87 @@ func showDiskInfo(logger *log.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to get disk IO statistics: %w", err)
 	}
+	iostats = filterIOStats(iostats)
+	sampled := time.Now()
 
 	fmt.Println(titleStyle.Render("Disk IO Statistics"))
-	columns = []table.Column{
-		{Title: "Device", Width: 15},
-		{Title: "Read Bytes", Width: 15},
-		{Title: "Write Bytes", Width: 15},
-		{Title: "Read Count", Width: 12},
-		{Title: "Write Count", Width: 12},
-		{Title: "Read Time", Width: 12},
-		{Title: "Write Time", Width: 12},
+	columns = []table.Column{{Title: "Device", Width: 15}}
+	if showCounterTotals() {
+		columns = append(columns,
+			table.Column{Title: "Read Bytes", Width: 15},
+			table.Column{Title: "Write Bytes", Width: 15},
+			table.Column{Title: "Read Count", Width: 12},
+			table.Column{Title: "Write Count", Width: 12},
+			table.Column{Title: "Read Time", Width: 12},
+			table.Column{Title: "Write Time", Width: 12},
+			table.Column{Title: "Read Lat", Width: 10},
+			table.Column{Title: "Write Lat", Width: 10},
+		)
+		if diskExtended {
+			columns = append(columns,
+				table.Column{Title: "Rd Merged", Width: 12},
+				table.Column{Title: "Wr Merged", Width: 12},
+			)
+		}
+	}
+	if diskExtended {
+		columns = append(columns, table.Column{Title: "In Flight", Width: 10})
+		if showCounterTotals() {
+			columns = append(columns, table.Column{Title: "Weighted IO", Width: 12})
+		}
+	}
+	if showCounterRates() {
+		columns = append(columns,
+			table.Column{Title: "Read/s", Width: 12},
+			table.Column{Title: "Write/s", Width: 12},
+		)
+	}
+
+	sortedBy := map[string]string{"name": "Device", "read": "Read Bytes", "write": "Write Bytes"}[diskSort]
+	if !showCounterTotals() {
+		// With --current only the rates are shown, so they carry the arrow.
+		sortedBy = map[string]string{"name": "Device", "read": "Read/s", "write": "Write/s"}[diskSort]
 	}
+	columns = markSorted(columns, sortedBy, diskSort != "name")
 
 	rows = nil
-	for name, stat := range iostats {
-		rows = append(rows, table.Row{
-			name,
-			humanize.Bytes(stat.ReadBytes),
-			humanize.Bytes(stat.WriteBytes),
-			fmt.Sprintf("%d", stat.ReadCount),
-			fmt.Sprintf("%d", stat.WriteCount),
-			fmt.Sprintf("%dms", stat.ReadTime),
-			fmt.Sprintf("%dms", stat.WriteTime),
-		})
+	for _, name := range sortedIODevices(iostats) {
+		stat := iostats[name]
+		row := table.Row{name}
+		if showCounterTotals() {
+			row = append(row,
+				humanize.Bytes(stat.ReadBytes),
+				humanize.Bytes(stat.WriteBytes),
+				fmt.Sprintf("%d", stat.ReadCount),
+				fmt.Sprintf("%d", stat.WriteCount),
+				fmt.Sprintf("%dms", stat.ReadTime),
+				fmt.Sprintf("%dms", stat.WriteTime),
+				formatLatency(avgLatency(stat.ReadTime, stat.ReadCount)),
+				formatLatency(avgLatency(stat.WriteTime, stat.WriteCount)),
+			)
+			if diskExtended {
+				row = append(row,
+					fmt.Sprintf("%d", stat.MergedReadCount),
+					fmt.Sprintf("%d", stat.MergedWriteCount),
+				)
+			}
+		}
+		if diskExtended {
+			// In-flight IO is a gauge, not a counter, so it's always shown.
+			row = append(row, fmt.Sprintf("%d", stat.IopsInProgress))
+			if showCounterTotals() {
+				row = append(row, fmt.Sprintf("%dms", stat.WeightedIO))
+			}
+		}
+		if showCounterRates() {
+			read, readOK := rates.Rate(name+"/read", stat.ReadBytes, sampled)
+			write, writeOK := rates.Rate(name+"/write", stat.WriteBytes, sampled)
+			if readOK && writeOK {
+				watchStats.Observe(name+" IO/s", read+write, formatRate)
+			}
+			row = append(row, rates.FormatRate(read, readOK), rates.FormatRate(write, writeOK))
+		}
+		rows = append(rows, row)
 	}
 
 	t = NewTable(columns, rows)
@@ -116,51 +205,358 @@ func showDiskInfo(logger *log.Logger) error {
 	return nil
 }
 
-func showRawDiskInfo() error {
+type diskInfo struct {
+	Host       string          `json:"host"`
+	Partitions []partitionInfo `json:"partitions"`
+	IO         []diskIOInfo    `json:"io"`
+}
+
+type partitionInfo struct {
+	Device      string  `json:"device"`
+	Mountpoint  string  `json:"mountpoint"`
+	Fstype      string  `json:"fstype"`
+	TotalBytes  uint64  `json:"total_bytes"`
+	UsedBytes   uint64  `json:"used_bytes"`
+	FreeBytes   uint64  `json:"free_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+type diskIOInfo struct {
+	Device      string `json:"device"`
+	ReadBytes   uint64 `json:"read_bytes"`
+	WriteBytes  uint64 `json:"write_bytes"`
+	ReadCount   uint64 `json:"read_count"`
+	WriteCount  uint64 `json:"write_count"`
+	ReadTimeMs  uint64 `json:"read_time_ms"`
+	WriteTimeMs uint64 `json:"write_time_ms"`
+	// ReadLatencyMs and WriteLatencyMs are the average time per operation
+	// since boot, or 0 when there have been none.
+	ReadLatencyMs  float64 `json:"read_latency_ms"`
+	WriteLatencyMs float64 `json:"write_latency_ms"`
+	// The iostat -x figures, shown in tables with --extended.
+	MergedReadCount  uint64 `json:"merged_read_count"`
+	MergedWriteCount uint64 `json:"merged_write_count"`
+	IopsInProgress   uint64 `json:"iops_in_progress"`
+	WeightedIOMs     uint64 `json:"weighted_io_ms"`
+}
+
+func showJSONDiskInfo() error {
+	info, err := collectDisk()
+	if err != nil {
+		return err
+	}
+	return renderStructured(info)
+}
+
+// collectDisk gathers partition usage and IO counters for structured output.
+func collectDisk() (diskInfo, error) {
 	partitions, err := disk.Partitions(false)
 	if err != nil {
-		return fmt.Errorf("failed to get disk partitions: %w", err)
+		return diskInfo{}, fmt.Errorf("failed to get disk partitions: %w", err)
 	}
+	partitions = filterPartitions(partitions)
 
-	fmt.Println("Disk Partitions:")
+	usage := make(map[string]*disk.UsageStat, len(partitions))
 	for _, partition := range partitions {
-		fmt.Printf("  Device: %s\n", partition.Device)
-		fmt.Printf("    Mount Point: %s\n", partition.Mountpoint)
-		fmt.Printf("    FS Type: %s\n", partition.Fstype)
-
-		usage, err := disk.Usage(partition.Mountpoint)
+		u, err := disk.Usage(partition.Mountpoint)
 		if err != nil {
-			fmt.Printf("    Usage: error: %v\n", err)
 			continue
 		}
-
-		fmt.Printf("    Total: %s\n", humanize.Bytes(usage.Total))
-		fmt.Printf("    Used: %s\n", humanize.Bytes(usage.Used))
-		fmt.Printf("    Free: %s\n", humanize.Bytes(usage.Free))
-		fmt.Printf("    Use%%: %.1f%%\n", usage.UsedPercent)
-		fmt.Println()
+		alerts.Threshold("disk:"+partition.Mountpoint, "disk_percent", u.UsedPercent, cfg.Thresholds.DiskPercent)
+		usage[partition.Mountpoint] = u
 	}
 
 	iostats, err := disk.IOCounters()
 	if err != nil {
-		return fmt.Errorf("failed to get disk IO statistics: %w", err)
+		return diskInfo{}, fmt.Errorf("failed to get disk IO statistics: %w", err)
+	}
+	iostats = filterIOStats(iostats)
+
+	info := buildDiskInfo(partitions, usage, iostats)
+	info.Host = hostTag()
+	return info, nil
+}
+
+// buildDiskInfo assembles structured output from gopsutil's disk figures,
+// keyed by mountpoint and device. Partitions without usage are skipped.
+// It doesn't touch the host, so it can be fed recorded data.
+func buildDiskInfo(partitions []disk.PartitionStat, usage map[string]*disk.UsageStat, iostats map[string]disk.IOCountersStat) diskInfo {
+	info := diskInfo{
+		Partitions: make([]partitionInfo, 0, len(partitions)),
+		IO:         make([]diskIOInfo, 0, len(iostats)),
+	}
+
+	for _, partition := range partitions {
+		u, ok := usage[partition.Mountpoint]
+		if !ok {
+			continue
+		}
+		info.Partitions = append(info.Partitions, partitionInfo{
+			Device:      partition.Device,
+			Mountpoint:  partition.Mountpoint,
+			Fstype:      partition.Fstype,
+			TotalBytes:  u.Total,
+			UsedBytes:   u.Used,
+			FreeBytes:   u.Free,
+			UsedPercent: u.UsedPercent,
+		})
+	}
+
+	for _, name := range sortedIODevices(iostats) {
+		stat := iostats[name]
+		info.IO = append(info.IO, diskIOInfo{
+			Device:           name,
+			ReadBytes:        stat.ReadBytes,
+			WriteBytes:       stat.WriteBytes,
+			ReadCount:        stat.ReadCount,
+			WriteCount:       stat.WriteCount,
+			ReadTimeMs:       stat.ReadTime,
+			WriteTimeMs:      stat.WriteTime,
+			ReadLatencyMs:    avgLatency(stat.ReadTime, stat.ReadCount),
+			WriteLatencyMs:   avgLatency(stat.WriteTime, stat.WriteCount),
+			MergedReadCount:  stat.MergedReadCount,
+			MergedWriteCount: stat.MergedWriteCount,
+			IopsInProgress:   stat.IopsInProgress,
+			WeightedIOMs:     stat.WeightedIO,
+		})
 	}
 
-	fmt.Println("Disk IO Statistics:")
+	return info
+}
+
+// diskPoints converts collected disk figures to InfluxDB points.
+func diskPoints(info diskInfo, at time.Time) []influxPoint {
+	points := make([]influxPoint, 0, len(info.Partitions)+len(info.IO))
+	for _, p := range info.Partitions {
+		points = append(points, influxPoint{
+			measurement: "disk",
+			tags: []influxTag{
+				{"device", p.Device},
+				{"mountpoint", p.Mountpoint},
+				{"fstype", p.Fstype},
+			},
+			fields: []influxField{
+				{"total_bytes", p.TotalBytes},
+				{"used_bytes", p.UsedBytes},
+				{"free_bytes", p.FreeBytes},
+				{"used_percent", p.UsedPercent},
+			},
+			time: at,
+		})
+	}
+
+	for _, io := range info.IO {
+		points = append(points, influxPoint{
+			measurement: "diskio",
+			tags:        []influxTag{{"device", io.Device}},
+			fields: []influxField{
+				{"read_bytes", io.ReadBytes},
+				{"write_bytes", io.WriteBytes},
+				{"read_count", io.ReadCount},
+				{"write_count", io.WriteCount},
+				{"read_time_ms", io.ReadTimeMs},
+				{"write_time_ms", io.WriteTimeMs},
+				{"read_latency_ms", io.ReadLatencyMs},
+				{"write_latency_ms", io.WriteLatencyMs},
+				{"merged_read_count", io.MergedReadCount},
+				{"merged_write_count", io.MergedWriteCount},
+				{"iops_in_progress", io.IopsInProgress},
+				{"weighted_io_ms", io.WeightedIOMs},
+			},
+			time: at,
+		})
+	}
+
+	return points
+}
+
+// fillTracker estimates when filesystems will run out of space from the
+// change in used bytes between successive samples of each mountpoint.
+type fillTracker struct {
+	prev map[string]rateSample
+}
+
+func newFillTracker() *fillTracker {
+	return &fillTracker{prev: make(map[string]rateSample)}
+}
+
+// Estimate records usage for mount and returns a time-to-full estimate such
+// as "~3h". Mounts that aren't growing, or have no previous sample, get "-".
+func (f *fillTracker) Estimate(mount string, usage *disk.UsageStat, at time.Time) string {
+	prev, ok := f.prev[mount]
+	f.prev[mount] = rateSample{value: usage.Used, at: at}
+	if !ok || usage.Used <= prev.value {
+		return "-"
+	}
+
+	elapsed := at.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		return "-"
+	}
+
+	growth := float64(usage.Used-prev.value) / elapsed
+	// A nearly idle disk can be centuries from full, beyond what a
+	// Duration holds; converting would overflow to a negative "<1m".
+	secs := float64(usage.Free) / growth
+	if secs > float64(math.MaxInt64/int64(time.Second)) {
+		return "-"
+	}
+	return "~" + formatETA(time.Duration(secs*float64(time.Second)))
+}
+
+// formatETA renders a duration coarsely, in the largest sensible unit.
+func formatETA(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// avgLatency returns the average milliseconds per operation from a device's
+// cumulative time and operation count, or 0 if it has done none.
+func avgLatency(timeMs, count uint64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(timeMs) / float64(count)
+}
+
+// formatLatency renders an average latency, or "-" for a device with no
+// operations. A consistently high figure is the signature of a failing disk.
+func formatLatency(ms float64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2fms", ms)
+}
+
+// filterPartitions keeps the partitions on devices selected by --device and
+// --exclude-device, and mounted where --mount asks.
+func filterPartitions(partitions []disk.PartitionStat) []disk.PartitionStat {
+	if len(diskDevices) == 0 && len(diskExcludeDevices) == 0 && len(diskMounts) == 0 {
+		return partitions
+	}
+
+	filtered := make([]disk.PartitionStat, 0, len(partitions))
+	for _, partition := range partitions {
+		if deviceSelected(partition.Device) && mountSelected(partition.Mountpoint) {
+			filtered = append(filtered, partition)
+		}
+	}
+	return filtered
+}
+
+// filterIOStats keeps the IO counters of devices selected by --device and
+// --exclude-device.
+func filterIOStats(iostats map[string]disk.IOCountersStat) map[string]disk.IOCountersStat {
+	if len(diskDevices) == 0 && len(diskExcludeDevices) == 0 {
+		return iostats
+	}
+
+	filtered := make(map[string]disk.IOCountersStat, len(iostats))
 	for name, stat := range iostats {
-		fmt.Printf("  Device: %s\n", name)
-		fmt.Printf("    Read Bytes: %s\n", humanize.Bytes(stat.ReadBytes))
-		fmt.Printf("    Write Bytes: %s\n", humanize.Bytes(stat.WriteBytes))
-		fmt.Printf("    Read Count: %d\n", stat.ReadCount)
-		fmt.Printf("    Write Count: %d\n", stat.WriteCount)
-		fmt.Printf("    Read Time: %dms\n", stat.ReadTime)
-		fmt.Printf("    Write Time: %dms\n", stat.WriteTime)
-		fmt.Println()
+		if deviceSelected(name) {
+			filtered[name] = stat
+		}
 	}
+	return filtered
+}
 
-	return nil
+// deviceSelected reports whether device passes --device and
+// --exclude-device. Devices match by prefix with or without /dev/, so "sda"
+// covers /dev/sda1 and "nvme0n1" covers nvme0n1p2.
+func deviceSelected(device string) bool {
+	name := strings.TrimPrefix(device, "/dev/")
+	matches := func(prefixes []string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, strings.TrimPrefix(prefix, "/dev/")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(diskDevices) > 0 && !matches(diskDevices) {
+		return false
+	}
+	return !matches(diskExcludeDevices)
+}
+
+// mountSelected reports whether mount is one of --mount, which matches
+// mountpoints exactly so "/" doesn't select every filesystem.
+func mountSelected(mount string) bool {
+	if len(diskMounts) == 0 {
+		return true
+	}
+	for _, m := range diskMounts {
+		if m == mount || (len(m) > 1 && strings.TrimSuffix(m, "/") == mount) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedIODevices returns the device names in iostats ordered by --sort, so
+// rows keep their position between refreshes in watch mode. Ties, and the
+// default "name" order, fall back to the device name.
+func sortedIODevices(iostats map[string]disk.IOCountersStat) []string {
+	names := make([]string, 0, len(iostats))
+	for name := range iostats {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		a, b := iostats[names[i]], iostats[names[j]]
+		switch diskSort {
+		case "read":
+			if a.ReadBytes != b.ReadBytes {
+				return a.ReadBytes > b.ReadBytes
+			}
+		case "write":
+			if a.WriteBytes != b.WriteBytes {
+				return a.WriteBytes > b.WriteBytes
+			}
+		}
+		return names[i] < names[j]
+	})
+	return names
 }
 
 func init() {
+	diskCmd.Flags().StringVar(&diskSort, "sort", "name", "order of the IO statistics table (name, read, write)")
+	diskCmd.Flags().StringSliceVar(&diskDevices, "device", nil, "only show these devices, matched by prefix (e.g. sda,nvme0n1)")
+	diskCmd.Flags().StringSliceVar(&diskExcludeDevices, "exclude-device", nil, "hide these devices, matched by prefix (e.g. loop,ram)")
+	diskCmd.Flags().StringSliceVar(&diskMounts, "mount", nil, "only show filesystems mounted at these paths (e.g. /,/var)")
+	diskCmd.Flags().BoolVar(&diskExtended, "extended", false, "add the iostat -x columns: merged requests, in-flight IO and weighted IO time")
+	registerColumns(diskCmd, []columnDoc{
+		{"device", "Device", "block device (usage and IO tables)"},
+		{"mountpoint", "Mount", "where the filesystem is mounted"},
+		{"fstype", "FS Type", "filesystem type"},
+		{"total", "Total", "filesystem size"},
+		{"used", "Used", "space in use"},
+		{"free", "Free", "space available"},
+		{"used_percent", "Use%", "share of the filesystem in use"},
+		{"full_in", "Full In", "estimated time until full (watch mode)"},
+		{"read_bytes", "Read Bytes", "bytes read since boot"},
+		{"write_bytes", "Write Bytes", "bytes written since boot"},
+		{"read_count", "Read Count", "read operations since boot"},
+		{"write_count", "Write Count", "write operations since boot"},
+		{"read_time", "Read Time", "time spent reading since boot"},
+		{"write_time", "Write Time", "time spent writing since boot"},
+		{"read_latency", "Read Lat", "average time per read since boot"},
+		{"write_latency", "Write Lat", "average time per write since boot"},
+		{"merged_reads", "Rd Merged", "adjacent reads merged into one (--extended)"},
+		{"merged_writes", "Wr Merged", "adjacent writes merged into one (--extended)"},
+		{"in_flight", "In Flight", "operations currently queued (--extended)"},
+		{"weighted_io", "Weighted IO", "time spent doing IO weighted by queue depth (--extended)"},
+		{"read_rate", "Read/s", "read throughput (watch mode)"},
+		{"write_rate", "Write/s", "write throughput (watch mode)"},
+	})
 	rootCmd.AddCommand(diskCmd)
 }
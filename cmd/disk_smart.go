@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+// reallocatedSectorsID is the ATA SMART attribute counting sectors the drive
+// has remapped after failing. Any at all are an early sign of wear.
+const reallocatedSectorsID = 5
+
+var diskSmartCmd = &cobra.Command{
+	Use:   "smart [device...]",
+	Short: "Show SMART health of physical disks",
+	Long: `Show the SMART health of physical disks as reported by smartctl, from
+smartmontools: the drive's overall self-assessment, temperature, reallocated
+sectors (ATA) or media errors and wear (NVMe), and power-on hours.
+
+Devices are found with smartctl --scan unless given, e.g. /dev/sda. Disks
+in standby are skipped rather than woken up. Reading SMART data usually
+takes root.
+
+Exits non-zero if any disk fails its health self-assessment.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		if _, err := exec.LookPath("smartctl"); err != nil {
+			return errors.New("smartctl not found: install smartmontools to read SMART data")
+		}
+
+		var failed, total int
+		err := runWatch(cmd.Context(), func() error {
+			var err error
+			failed, total, err = showSmart(cmd.Context(), logger, args)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if failed > 0 && !watchOutput {
+			return fmt.Errorf("%d of %d disks failed their SMART health check", failed, total)
+		}
+		return nil
+	},
+}
+
+// smartInfo is a disk's SMART health. Fields the drive doesn't report, or
+// that don't apply to its protocol, are left out.
+type smartInfo struct {
+	Device   string `json:"device"`
+	Protocol string `json:"protocol,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Serial   string `json:"serial,omitempty"`
+	// Passed is the drive's overall self-assessment, PASSED or FAILED in
+	// smartctl -H.
+	Passed             *bool  `json:"passed,omitempty"`
+	TemperatureC       *int   `json:"temperature_celsius,omitempty"`
+	ReallocatedSectors *int64 `json:"reallocated_sectors,omitempty"`
+	MediaErrors        *int64 `json:"media_errors,omitempty"`
+	// PercentUsed is an NVMe drive's estimate of how much of its rated
+	// endurance is used up; it can pass 100.
+	PercentUsed  *int   `json:"percent_used,omitempty"`
+	PowerOnHours *int64 `json:"power_on_hours,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Failing reports whether the drive failed its self-assessment.
+func (s smartInfo) Failing() bool {
+	return s.Passed != nil && !*s.Passed
+}
+
+// Worn reports whether the drive is still passing but has started
+// remapping sectors or hitting media errors.
+func (s smartInfo) Worn() bool {
+	return (s.ReallocatedSectors != nil && *s.ReallocatedSectors > 0) ||
+		(s.MediaErrors != nil && *s.MediaErrors > 0)
+}
+
+func showSmart(ctx context.Context, logger *log.Logger, devices []string) (int, int, error) {
+	logger.Debug("gathering SMART information")
+	defer timeCollector(logger, "smart")()
+
+	disks, err := collectSmart(ctx, devices)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var failed int
+	var highlight []int
+	for i, d := range disks {
+		if d.Failing() {
+			failed++
+		}
+		if d.Failing() || d.Worn() {
+			highlight = append(highlight, i)
+		}
+		if d.Error != "" {
+			logger.Debug("failed to read SMART data", "device", d.Device, "error", d.Error)
+		}
+	}
+
+	if structuredOutput() {
+		return failed, len(disks), renderStructured(disks)
+	}
+
+	fmt.Println(titleStyle.Render("SMART Health"))
+	if len(disks) == 0 {
+		fmt.Println("No disks found by smartctl --scan")
+		return 0, 0, nil
+	}
+
+	columns := []table.Column{
+		{Title: "Device", Width: 14},
+		{Title: "Model", Width: 24},
+		{Title: "Health", Width: 7},
+		{Title: "Temp", Width: 5},
+		{Title: "Realloc", Width: 7},
+		{Title: "Media Err", Width: 9},
+		{Title: "Wear%", Width: 5},
+		{Title: "Power On", Width: 9},
+		{Title: "Error", Width: 40},
+	}
+
+	var rows []table.Row
+	for _, d := range disks {
+		health := "-"
+		if d.Passed != nil {
+			health = "PASSED"
+			if !*d.Passed {
+				health = "FAILED"
+			}
+		}
+		temp := "-"
+		if d.TemperatureC != nil {
+			temp = fmt.Sprintf("%d°C", *d.TemperatureC)
+		}
+		powerOn := "-"
+		if d.PowerOnHours != nil {
+			powerOn = fmt.Sprintf("%dh", *d.PowerOnHours)
+		}
+		errText := d.Error
+		if len(errText) > 40 {
+			errText = errText[:37] + "..."
+		}
+		rows = append(rows, table.Row{
+			d.Device,
+			d.Model,
+			health,
+			temp,
+			optionalInt(d.ReallocatedSectors),
+			optionalInt(d.MediaErrors),
+			optionalInt(d.PercentUsed),
+			powerOn,
+			errText,
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, highlight)))
+	return failed, len(disks), nil
+}
+
+// optionalInt formats a value the drive may not report, "-" when it didn't.
+func optionalInt[T int | int64](v *T) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatInt(int64(*v), 10)
+}
+
+// collectSmart reads the SMART data of devices, or of every disk smartctl
+// finds when there are none. A disk that can't be read, for lack of
+// permission or because it's asleep, gets an Error rather than failing the
+// rest.
+func collectSmart(ctx context.Context, devices []string) ([]smartInfo, error) {
+	// smartctl needs the device type it detected when scanning for disks
+	// behind RAID controllers and USB bridges.
+	types := make(map[string]string)
+	if len(devices) == 0 {
+		scanned, err := scanSmartDevices(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range scanned {
+			devices = append(devices, d.Name)
+			types[d.Name] = d.Type
+		}
+	}
+
+	disks := make([]smartInfo, 0, len(devices))
+	for _, device := range devices {
+		disks = append(disks, readSmart(ctx, device, types[device]))
+	}
+	return disks, nil
+}
+
+type smartDevice struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func scanSmartDevices(ctx context.Context) ([]smartDevice, error) {
+	out, err := exec.CommandContext(ctx, "smartctl", "--scan", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for disks: %w", err)
+	}
+	var scan struct {
+		Devices []smartDevice `json:"devices"`
+	}
+	if err := json.Unmarshal(out, &scan); err != nil {
+		return nil, fmt.Errorf("failed to parse smartctl --scan output: %w", err)
+	}
+	return scan.Devices, nil
+}
+
+// smartctlReport is the part of smartctl --json output systat reads.
+type smartctlReport struct {
+	Smartctl struct {
+		ExitStatus int `json:"exit_status"`
+		Messages   []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	Device struct {
+		Protocol string `json:"protocol"`
+	} `json:"device"`
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SmartStatus  *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	ATAAttributes *struct {
+		Table []struct {
+			ID  int `json:"id"`
+			Raw struct {
+				Value int64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeLog *struct {
+		MediaErrors    int64 `json:"media_errors"`
+		PercentageUsed int   `json:"percentage_used"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+// readSmart runs smartctl on one device. smartctl's exit status is a bit
+// mask: the low two bits mean the device couldn't be read at all, while the
+// others flag what it found and still come with a full report.
+func readSmart(ctx context.Context, device, deviceType string) smartInfo {
+	info := smartInfo{Device: device}
+
+	args := []string{"--json", "--info", "--health", "--attributes", "--nocheck=standby"}
+	if deviceType != "" {
+		args = append(args, "--device="+deviceType)
+	}
+	out, err := exec.CommandContext(ctx, "smartctl", append(args, device)...).Output()
+
+	var report smartctlReport
+	if jsonErr := json.Unmarshal(out, &report); jsonErr != nil {
+		if err == nil {
+			err = jsonErr
+		}
+		info.Error = fmt.Sprintf("failed to run smartctl: %v", err)
+		return info
+	}
+
+	if report.Smartctl.ExitStatus&0b11 != 0 {
+		info.Error = smartctlError(report)
+		return info
+	}
+
+	info.Protocol = report.Device.Protocol
+	info.Model = report.ModelName
+	info.Serial = report.SerialNumber
+	if report.SmartStatus != nil {
+		info.Passed = &report.SmartStatus.Passed
+	}
+	if report.Temperature != nil {
+		info.TemperatureC = &report.Temperature.Current
+	}
+	if report.PowerOnTime != nil {
+		info.PowerOnHours = &report.PowerOnTime.Hours
+	}
+	if report.ATAAttributes != nil {
+		for _, attr := range report.ATAAttributes.Table {
+			if attr.ID == reallocatedSectorsID {
+				info.ReallocatedSectors = &attr.Raw.Value
+			}
+		}
+	}
+	if report.NVMeLog != nil {
+		info.MediaErrors = &report.NVMeLog.MediaErrors
+		info.PercentUsed = &report.NVMeLog.PercentageUsed
+	}
+	return info
+}
+
+// smartctlError explains why smartctl couldn't read a device, from the
+// messages in its report.
+func smartctlError(report smartctlReport) string {
+	var messages []string
+	for _, m := range report.Smartctl.Messages {
+		messages = append(messages, strings.TrimSpace(m.String))
+	}
+	msg := strings.Join(messages, "; ")
+	switch {
+	case strings.Contains(msg, "Permission denied"):
+		return "permission denied: SMART data needs root"
+	case strings.Contains(strings.ToUpper(msg), "STANDBY"):
+		return "in standby, skipped to avoid spinning it up"
+	case msg == "":
+		return fmt.Sprintf("smartctl exited with status %d", report.Smartctl.ExitStatus)
+	default:
+		return msg
+	}
+}
+
+func init() {
+	registerColumns(diskSmartCmd, []columnDoc{
+		{"device", "Device", "disk device, e.g. /dev/sda"},
+		{"model", "Model", "drive model"},
+		{"health", "Health", "overall SMART self-assessment, PASSED or FAILED"},
+		{"temperature", "Temp", "drive temperature"},
+		{"reallocated", "Realloc", "sectors remapped after failing (ATA)"},
+		{"media_errors", "Media Err", "unrecovered data integrity errors (NVMe)"},
+		{"wear", "Wear%", "share of rated endurance used up (NVMe)"},
+		{"power_on", "Power On", "hours the drive has been powered on"},
+		{"error", "Error", "why SMART data couldn't be read"},
+	})
+	diskCmd.AddCommand(diskSmartCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+func TestFillTrackerEstimate(t *testing.T) {
+	tests := []struct {
+		name         string
+		used1, used2 uint64
+		free         uint64
+		want         string
+	}{
+		{"not growing", 100, 100, 1000, "-"},
+		{"shrinking", 200, 100, 1000, "-"},
+		{"full in an hour and a half", 0, 100, 540_000, "~1h"},
+		{"nearly idle", 0, 50, 5e11, "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFillTracker()
+			start := time.Now()
+			f.Estimate("/", &disk.UsageStat{Used: tt.used1}, start)
+			got := f.Estimate("/", &disk.UsageStat{Used: tt.used2, Free: tt.free}, start.Add(time.Second))
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
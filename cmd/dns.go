@@ -1,58 +1,130 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"strings"
 
-	"github.com/alecthomas/chroma/quick"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/miekg/dns"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 const (
 	dnsServer    = "10.0.0.1:53"
 	adminUDSDev  = ".admin.uds.dev"
 	udsDevDomain = ".uds.dev"
+	// dnsUDPSize is the UDP payload size advertised with EDNS0, large enough
+	// for the signatures DNSSEC adds to a response.
+	dnsUDPSize = 4096
+)
+
+var (
+	dnsNoDNSSEC bool
+	dnsShort    bool
 )
 
 var dnsCmd = &cobra.Command{
 	Use:   "dns [domain]",
 	Short: "Query DNS information for a domain",
 	Long: `Query DNS information for a domain under *.admin.uds.dev or *.uds.dev.
-Example: systat dns keycloak.admin.uds.dev`,
-	Args: cobra.ExactArgs(1),
+Example: systat dns keycloak.admin.uds.dev
+
+The output starts with the server that answered and the query time, like
+dig's footer, followed by the response.
+
+Queries set the DNSSEC OK (DO) bit, and the output reports whether the
+server validated the answer (the AD flag). A server that doesn't validate
+never sets AD, so "authenticated: false" means unvalidated rather than
+forged. --no-dnssec sends a plain query instead.
+
+--short prints only the answers, one per line, like dig +short, for use
+in scripts.
+
+Use "systat dns config" to see the system's own resolver configuration.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{structuredOnly: ""},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := log.FromContext(cmd.Context())
 		domain := args[0]
 
+		if dnsShort && (jsonOutput || outputFormat == "yaml" || query != nil) {
+			return errors.New("--short can't be combined with --json, -o yaml or --query")
+		}
+
 		logger.Debug("querying DNS", "domain", domain)
 
 		msg := new(dns.Msg)
 		msg.SetQuestion(dns.Fqdn(domain), dns.TypeA)
+		if !dnsNoDNSSEC {
+			// AD in a query asks the server to report validation even
+			// without DO; DO also asks for the signatures themselves.
+			msg.SetEdns0(dnsUDPSize, true)
+			msg.AuthenticatedData = true
+		}
 
 		client := new(dns.Client)
-		resp, _, err := client.Exchange(msg, dnsServer)
+		resp, rtt, err := client.Exchange(msg, dnsServer)
 		if err != nil {
 			return fmt.Errorf("DNS query failed: %w", err)
 		}
 
-		b, err := yaml.Marshal(resp)
-		if err != nil {
-			return fmt.Errorf("failed to marshal response: %w", err)
+		if dnsShort {
+			for _, value := range answerValues(resp) {
+				fmt.Println(value)
+			}
+			return nil
 		}
 
-		style := "catppuccin-latte"
-		if lipgloss.HasDarkBackground() {
-			style = "catppuccin-frappe"
+		result := dnsResult{
+			Server:      dnsServer,
+			QueryTimeMs: float64(rtt.Microseconds()) / 1000,
+			DNSSEC: dnssecStatus{
+				DO:            !dnsNoDNSSEC,
+				Authenticated: resp.AuthenticatedData,
+			},
+			Response: resp,
 		}
 
-		return quick.Highlight(os.Stdout, string(b), "yaml", "terminal256", style)
+		// The response has no table form, so it's always structured.
+		return renderStructured(result)
 	},
 }
 
+// answerValues returns the data of each record in the answer section, e.g.
+// just the address of an A record. The signatures DNSSEC adds are left out,
+// as dig leaves them out unless asked for.
+func answerValues(resp *dns.Msg) []string {
+	values := make([]string, 0, len(resp.Answer))
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype == dns.TypeRRSIG {
+			continue
+		}
+		values = append(values, strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+	return values
+}
+
+// dnsResult is a DNS response and what systat knows about the query that
+// got it.
+type dnsResult struct {
+	// Server is the server that answered, as host:port.
+	Server string `json:"server"`
+	// QueryTimeMs is the round trip, as dig's "Query time".
+	QueryTimeMs float64      `json:"query_time_ms"`
+	DNSSEC      dnssecStatus `json:"dnssec"`
+	Response    *dns.Msg     `json:"response"`
+}
+
+// dnssecStatus reports whether DNSSEC was asked for and whether the server
+// says it validated the answer.
+type dnssecStatus struct {
+	DO            bool `json:"do"`
+	Authenticated bool `json:"authenticated"`
+}
+
 func init() {
+	dnsCmd.Flags().BoolVar(&dnsShort, "short", false, "print only the answers, one per line, like dig +short")
+	dnsCmd.Flags().BoolVar(&dnsNoDNSSEC, "no-dnssec", false, "don't set the DNSSEC OK (DO) bit in the query")
 	rootCmd.AddCommand(dnsCmd)
 }
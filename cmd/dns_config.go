@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	resolvConfPath = "/etc/resolv.conf"
+	// resolvedUpstreamPath is where systemd-resolved lists the servers it
+	// forwards to, in resolv.conf format.
+	resolvedUpstreamPath = "/run/systemd/resolve/resolv.conf"
+	// resolvedStubAddress is systemd-resolved's local stub resolver, which
+	// resolv.conf points at when resolved manages it.
+	resolvedStubAddress = "127.0.0.53"
+)
+
+var dnsConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the system's resolver configuration",
+	Long: `Show where the system resolver sends queries: the name servers, search
+domains and options in /etc/resolv.conf.
+
+When resolv.conf points at systemd-resolved's local stub (127.0.0.53), the
+upstream servers it forwards to are read from
+/run/systemd/resolve/resolv.conf and shown as well.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+		return showResolverConfig(logger)
+	},
+}
+
+// resolverConfig is the parsed contents of a resolv.conf.
+type resolverConfig struct {
+	Source      string   `json:"source"`
+	Nameservers []string `json:"nameservers"`
+	Search      []string `json:"search,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	// Upstream is systemd-resolved's configuration, when Nameservers is
+	// its stub.
+	Upstream *resolverConfig `json:"upstream,omitempty"`
+}
+
+func showResolverConfig(logger *log.Logger) error {
+	logger.Debug("reading resolver configuration")
+	defer timeCollector(logger, "dns config")()
+
+	conf, err := readResolverConfig(resolvConfPath)
+	if err != nil {
+		return err
+	}
+	if len(conf.Nameservers) == 1 && conf.Nameservers[0] == resolvedStubAddress {
+		upstream, err := readResolverConfig(resolvedUpstreamPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("failed to read systemd-resolved upstream servers", "error", err)
+		}
+		if err == nil {
+			conf.Upstream = &upstream
+		}
+	}
+
+	if structuredOutput() {
+		return renderStructured(conf)
+	}
+
+	fmt.Println(titleStyle.Render("Resolver"))
+	columns := []table.Column{
+		{Title: "Setting", Width: 12},
+		{Title: "Value", Width: 50},
+		{Title: "Source", Width: 34},
+	}
+	rows := resolverRows(conf, nil)
+	if conf.Upstream != nil {
+		rows = resolverRows(*conf.Upstream, rows)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, noResultsRow(columns))
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+	return nil
+}
+
+// resolverRows appends a row per setting in conf to rows.
+func resolverRows(conf resolverConfig, rows []table.Row) []table.Row {
+	for _, s := range conf.Nameservers {
+		rows = append(rows, table.Row{"nameserver", s, conf.Source})
+	}
+	for _, s := range conf.Search {
+		rows = append(rows, table.Row{"search", s, conf.Source})
+	}
+	for _, s := range conf.Options {
+		rows = append(rows, table.Row{"option", s, conf.Source})
+	}
+	return rows
+}
+
+// readResolverConfig parses a resolv.conf. As in glibc, "domain" and
+// "search" replace each other, so the last one wins, while options
+// accumulate.
+func readResolverConfig(path string) (resolverConfig, error) {
+	conf := resolverConfig{Source: path, Nameservers: []string{}}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return conf, fmt.Errorf("failed to read resolver configuration: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			conf.Nameservers = append(conf.Nameservers, fields[1])
+		case "domain", "search":
+			conf.Search = fields[1:]
+		case "options":
+			conf.Options = append(conf.Options, fields[1:]...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return conf, fmt.Errorf("failed to read resolver configuration: %w", err)
+	}
+	return conf, nil
+}
+
+func init() {
+	registerColumns(dnsConfigCmd, []columnDoc{
+		{"setting", "Setting", "nameserver, search domain or option"},
+		{"value", "Value", "the server address, domain or option"},
+		{"source", "Source", "file it was read from"},
+	})
+	dnsCmd.AddCommand(dnsConfigCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// randomRoot is where Linux reports the state of its random pool.
+const randomRoot = "/proc/sys/kernel/random"
+
+// entropyLowBits is the available entropy below which the metrics command
+// warns. Older kernels block /dev/random, and with it TLS handshakes and key
+// generation, when the pool runs this low.
+const entropyLowBits = 200
+
+// entropyInfo is the kernel random pool's available entropy and size, in
+// bits.
+type entropyInfo struct {
+	AvailableBits uint64 `json:"available_bits"`
+	PoolSizeBits  uint64 `json:"pool_size_bits"`
+}
+
+// Low reports whether entropy is critically low.
+func (e entropyInfo) Low() bool {
+	return e.AvailableBits < entropyLowBits
+}
+
+// readEntropy returns the random pool status. It returns nil without an
+// error on systems that don't expose it.
+func readEntropy() (*entropyInfo, error) {
+	available, err := readSysfsUint(filepath.Join(randomRoot, "entropy_avail"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read available entropy: %w", err)
+	}
+
+	// The pool size is informational; a missing file leaves it at 0.
+	poolSize, _ := readSysfsUint(filepath.Join(randomRoot, "poolsize"))
+	return &entropyInfo{AvailableBits: available, PoolSizeBits: poolSize}, nil
+}
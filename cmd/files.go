@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// fileNrPath holds the kernel's count of allocated, unused and maximum
+	// file handles.
+	fileNrPath = "/proc/sys/fs/file-nr"
+	// sockstatPath starts with a "sockets: used N" line.
+	sockstatPath = "/proc/net/sockstat"
+)
+
+// filesWarnPercent is the share of the file handle limit in use at which
+// the metrics command highlights it.
+const filesWarnPercent = 90
+
+// filesInfo is system-wide file descriptor and socket usage.
+type filesInfo struct {
+	OpenFiles   uint64  `json:"open_files"`
+	MaxFiles    uint64  `json:"max_files"`
+	UsedPercent float64 `json:"used_percent"`
+	Sockets     uint64  `json:"sockets"`
+}
+
+// readFiles returns file descriptor and socket totals. It returns nil
+// without an error on systems without /proc.
+func readFiles() (*filesInfo, error) {
+	b, err := os.ReadFile(fileNrPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file handle counts: %w", err)
+	}
+
+	fields := strings.Fields(string(b))
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("failed to parse %s: unexpected format %q", fileNrPath, b)
+	}
+	var counts [3]uint64
+	for i, field := range fields {
+		if counts[i], err = strconv.ParseUint(field, 10, 64); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", fileNrPath, err)
+		}
+	}
+
+	// Kernels since 2.6 always report 0 unused, but older ones counted
+	// freed handles as allocated.
+	info := &filesInfo{
+		OpenFiles: counts[0] - counts[1],
+		MaxFiles:  counts[2],
+	}
+	if info.MaxFiles > 0 {
+		info.UsedPercent = float64(info.OpenFiles) / float64(info.MaxFiles) * 100
+	}
+
+	// Socket counts are a nice-to-have; the handle counts stand alone.
+	if b, err := os.ReadFile(sockstatPath); err == nil {
+		line, _, _ := strings.Cut(string(b), "\n")
+		if used, ok := strings.CutPrefix(line, "sockets: used "); ok {
+			info.Sockets, _ = strconv.ParseUint(strings.TrimSpace(used), 10, 64)
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// healthAddr is set by --listen.
+var healthAddr string
+
+// health records how each collector has been doing, for /healthz. Commands
+// record through runWatch under their own name (disk, k8s events, ...); the
+// dashboard records each of its collectors separately.
+var health = &healthState{
+	started:    time.Now(),
+	collectors: make(map[string]*collectorHealth),
+}
+
+// staleIntervals is how many refresh intervals a collector may go without
+// succeeding before /healthz reports it as stale.
+const staleIntervals = 3
+
+type healthState struct {
+	mu         sync.Mutex
+	started    time.Time
+	interval   time.Duration
+	collectors map[string]*collectorHealth
+}
+
+type collectorHealth struct {
+	LastRun     time.Time  `json:"last_run"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	TookMs      float64    `json:"took_ms"`
+	Error       string     `json:"error,omitempty"`
+	Stale       bool       `json:"stale,omitempty"`
+}
+
+// healthReport is the body of /healthz.
+type healthReport struct {
+	Status        string                      `json:"status"`
+	Started       time.Time                   `json:"started"`
+	UptimeSeconds float64                     `json:"uptime_seconds"`
+	Interval      string                      `json:"interval,omitempty"`
+	Collectors    map[string]*collectorHealth `json:"collectors"`
+	Stale         []string                    `json:"stale,omitempty"`
+}
+
+// Expect sets how often collectors are expected to run. Until it's set, as
+// for one-shot commands, nothing is ever stale.
+func (h *healthState) Expect(interval time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.interval = interval
+}
+
+// Collected records a run of the named collector finishing.
+func (h *healthState) Collected(name string, took time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c, ok := h.collectors[name]
+	if !ok {
+		c = &collectorHealth{}
+		h.collectors[name] = c
+	}
+	now := time.Now()
+	c.LastRun = now
+	c.TookMs = float64(took.Microseconds()) / 1000
+	c.Error = ""
+	if err != nil {
+		c.Error = err.Error()
+		return
+	}
+	c.LastSuccess = &now
+}
+
+// Report returns the current state. A collector is stale when it hasn't
+// succeeded for staleIntervals intervals, counting from startup for one that
+// never has; that's usually a wedged collector or one failing every time.
+func (h *healthState) Report(now time.Time) healthReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	report := healthReport{
+		Status:        "ok",
+		Started:       h.started,
+		UptimeSeconds: now.Sub(h.started).Round(time.Second).Seconds(),
+		Collectors:    make(map[string]*collectorHealth, len(h.collectors)),
+	}
+	if h.interval > 0 {
+		report.Interval = h.interval.String()
+	}
+
+	for name, c := range h.collectors {
+		copied := *c
+		if h.interval > 0 {
+			since := h.started
+			if c.LastSuccess != nil {
+				since = *c.LastSuccess
+			}
+			copied.Stale = now.Sub(since) > staleIntervals*h.interval
+		}
+		if copied.Stale {
+			report.Stale = append(report.Stale, name)
+		}
+		report.Collectors[name] = &copied
+	}
+	sort.Strings(report.Stale)
+	if len(report.Stale) > 0 {
+		report.Status = "stale"
+	}
+	return report
+}
+
+func (h *healthState) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	report := h.Report(time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(report)
+}
+
+// startHealthServer serves /healthz on addr for the life of the process, so
+// an orchestrator can check on a long-running systat itself. It answers 200
+// while every collector is keeping up and 503 once one goes stale, e.g.
+//
+//	systat metrics --watch --listen :8080
+//	curl localhost:8080/healthz
+func startHealthServer(ctx context.Context, logger *log.Logger, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to start health server: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", health)
+
+	logger.Debug("serving health endpoint", "addr", ln.Addr().String())
+	serve(ctx, logger, "health", ln, mux)
+	return nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	historyWindow time.Duration
+	historyFormat string
+)
+
+// ring is a fixed-size buffer that overwrites its oldest entry once full.
+type ring[T any] struct {
+	items []T
+	next  int
+	full  bool
+}
+
+func newRing[T any](size int) *ring[T] {
+	return &ring[T]{items: make([]T, size)}
+}
+
+// Push appends v, dropping the oldest entry when the buffer is full.
+func (r *ring[T]) Push(v T) {
+	r.items[r.next] = v
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Values returns a copy of the buffered entries, oldest first.
+func (r *ring[T]) Values() []T {
+	if !r.full {
+		return append([]T(nil), r.items[:r.next]...)
+	}
+	return append(append([]T(nil), r.items[r.next:]...), r.items[:r.next]...)
+}
+
+// historyPoint is one sample of a dashboard metric.
+type historyPoint struct {
+	At    time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// historySeries is the recent history of one metric, such as cpu_percent.
+type historySeries struct {
+	Metric string         `json:"metric"`
+	Points []historyPoint `json:"points"`
+}
+
+// metricHistory keeps the dashboard's last --history worth of samples per
+// metric, so "w" can write out what just happened without any logging
+// having been set up in advance.
+type metricHistory struct {
+	size    int
+	order   []string
+	series  map[string]*ring[historyPoint]
+	netRate *rateTracker
+}
+
+// newMetricHistory returns a history holding window worth of samples taken
+// every interval.
+func newMetricHistory(window, interval time.Duration) *metricHistory {
+	size := int(window / interval)
+	if size < 1 {
+		size = 1
+	}
+	return &metricHistory{
+		size:    size,
+		series:  make(map[string]*ring[historyPoint]),
+		netRate: newRateTracker(),
+	}
+}
+
+// Record appends a sample of metric taken at at.
+func (h *metricHistory) Record(metric string, at time.Time, value float64) {
+	r, ok := h.series[metric]
+	if !ok {
+		r = newRing[historyPoint](h.size)
+		h.series[metric] = r
+		h.order = append(h.order, metric)
+	}
+	r.Push(historyPoint{At: at, Value: value})
+}
+
+// RecordNet records the combined receive and transmit rates of all
+// interfaces from their cumulative byte counters.
+func (h *metricHistory) RecordNet(rx, tx uint64, at time.Time) {
+	if rate, ok := h.netRate.Rate("rx", rx, at); ok {
+		h.Record("net_rx_bytes_per_sec", at, rate)
+	}
+	if rate, ok := h.netRate.Rate("tx", tx, at); ok {
+		h.Record("net_tx_bytes_per_sec", at, rate)
+	}
+}
+
+// Snapshot copies every series, in the order metrics were first recorded.
+func (h *metricHistory) Snapshot() []historySeries {
+	series := make([]historySeries, 0, len(h.order))
+	for _, metric := range h.order {
+		series = append(series, historySeries{
+			Metric: metric,
+			Points: h.series[metric].Values(),
+		})
+	}
+	return series
+}
+
+// fileWrittenMsg reports the outcome of writing the history or a snapshot
+// to disk.
+type fileWrittenMsg struct {
+	path string
+	err  error
+}
+
+// writeHistoryCmd writes series to a timestamped file in the working
+// directory, as CSV or JSON per --history-format.
+func writeHistoryCmd(series []historySeries) tea.Cmd {
+	return func() tea.Msg {
+		path := fmt.Sprintf("systat-history-%s.%s", time.Now().Format("20060102-150405"), historyFormat)
+		return fileWrittenMsg{path: path, err: writeHistory(path, series)}
+	}
+}
+
+func writeHistory(path string, series []historySeries) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+	defer f.Close()
+
+	if historyFormat == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(series); err != nil {
+			return fmt.Errorf("failed to write history: %w", err)
+		}
+		return f.Close()
+	}
+
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"time", "metric", "value"})
+	for _, s := range series {
+		for _, p := range s.Points {
+			_ = w.Write([]string{
+				p.At.Format(time.RFC3339),
+				s.Metric,
+				strconv.FormatFloat(p.Value, 'f', 2, 64),
+			})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
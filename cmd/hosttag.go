@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+var (
+	// hostTagOverride is set by --host-tag.
+	hostTagOverride string
+	// hostTagFQDN is set by --fqdn.
+	hostTagFQDN bool
+)
+
+var (
+	hostTagOnce  sync.Once
+	hostTagValue string
+)
+
+// hostTag identifies this machine in JSON, InfluxDB and StatsD output so
+// samples collected from a fleet can be told apart. It is --host-tag when
+// given, otherwise the hostname (fully qualified with --fqdn), falling back
+// to the host ID.
+//
+// In a container the hostname is usually the pod name; --host-tag can pass
+// the node name in instead, e.g. from the downward API.
+func hostTag() string {
+	if hostTagOverride != "" {
+		return hostTagOverride
+	}
+
+	hostTagOnce.Do(func() {
+		if name, err := os.Hostname(); err == nil && name != "" {
+			hostTagValue = name
+			if hostTagFQDN {
+				hostTagValue = fqdn(name)
+			}
+			return
+		}
+		if id, err := host.HostID(); err == nil {
+			hostTagValue = id
+		}
+	})
+	return hostTagValue
+}
+
+// fqdnTimeout bounds all the lookups fqdn makes, so a host with broken DNS
+// doesn't hang the command.
+const fqdnTimeout = 2 * time.Second
+
+// fqdn returns the fully qualified domain name of host as the resolver sees
+// it, or host itself when it can't be resolved to anything longer.
+func fqdn(host string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), fqdnTimeout)
+	defer cancel()
+
+	if cname, err := net.DefaultResolver.LookupCNAME(ctx, host); err == nil {
+		if name := strings.TrimSuffix(cname, "."); strings.Contains(name, ".") {
+			return name
+		}
+	}
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return host
+	}
+	for _, addr := range addrs {
+		names, err := net.DefaultResolver.LookupAddr(ctx, addr)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			if name = strings.TrimSuffix(name, "."); strings.Contains(name, ".") {
+				return name
+			}
+		}
+	}
+	return host
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// influxTimeout bounds how long a write to --influx-url may take.
+const influxTimeout = 10 * time.Second
+
+type influxTag struct {
+	key, value string
+}
+
+type influxField struct {
+	key   string
+	value any
+}
+
+// influxPoint is a single line of InfluxDB line protocol:
+//
+//	measurement,tag=value field=value timestamp
+type influxPoint struct {
+	measurement string
+	tags        []influxTag
+	fields      []influxField
+	time        time.Time
+}
+
+var influxEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`)
+
+func (p influxPoint) String() string {
+	var b strings.Builder
+	b.WriteString(influxEscaper.Replace(p.measurement))
+	for _, tag := range p.tags {
+		if tag.value == "" {
+			continue
+		}
+		b.WriteString("," + influxEscaper.Replace(tag.key) + "=" + influxEscaper.Replace(tag.value))
+	}
+
+	for i, field := range p.fields {
+		if i == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(influxEscaper.Replace(field.key) + "=" + influxValue(field.value))
+	}
+
+	b.WriteString(" " + strconv.FormatInt(p.time.UnixNano(), 10))
+	return b.String()
+}
+
+// influxValue formats a field value with the type suffix line protocol uses.
+func influxValue(v any) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v) + "i"
+	case int32:
+		return strconv.FormatInt(int64(v), 10) + "i"
+	case int64:
+		return strconv.FormatInt(v, 10) + "i"
+	case uint64:
+		return strconv.FormatUint(v, 10) + "i"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
+
+// pointOutput reports whether output goes to a metrics sink (InfluxDB line
+// protocol or StatsD) rather than being rendered for reading.
+func pointOutput() bool {
+	return outputFormat == "influx" || statsdAddr != ""
+}
+
+// emitPoints sends points to StatsD when --statsd is set, and otherwise
+// writes them as InfluxDB line protocol tagged with the host.
+func emitPoints(logger *log.Logger, points []influxPoint) error {
+	if statsdAddr != "" {
+		sendStatsd(logger, points)
+		return nil
+	}
+
+	host := influxTag{"host", hostTag()}
+	for i := range points {
+		points[i].tags = append(points[i].tags, host)
+	}
+	return writeInflux(points)
+}
+
+// writeInflux prints points as line protocol, or POSTs them to --influx-url
+// when it is set.
+func writeInflux(points []influxPoint) error {
+	var buf bytes.Buffer
+	for _, p := range points {
+		buf.WriteString(p.String())
+		buf.WriteByte('\n')
+	}
+
+	if influxURL == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), influxTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, influxURL, &buf)
+	if err != nil {
+		return fmt.Errorf("failed to create influx request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write to influx: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("influx write returned %s", resp.Status)
+	}
+	return nil
+}
@@ -4,16 +4,34 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
+// k8sNamespace limits the k8s subcommands to one namespace; empty means all.
+var k8sNamespace string
+
+// absoluteTime is set by --absolute-time or absolute_time in the config.
+var absoluteTime bool
+
+// formatAge shows how long ago t was, kubectl style (5m, 3d), or with
+// --absolute-time the time itself, for the age columns of the k8s commands
+// and the dashboard.
+func formatAge(t, now time.Time) string {
+	if absoluteTime {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	return duration.HumanDuration(now.Sub(t))
+}
+
 var k8sCmd = &cobra.Command{
 	Use:   "k8s",
 	Short: "Display Kubernetes cluster information",
@@ -31,28 +49,11 @@ Provides information about:
 
 func showK8sInfo(logger *log.Logger) error {
 	logger.Debug("gathering kubernetes information")
+	defer timeCollector(logger, "k8s")()
 
-	// Build kubeconfig path
-	home := homedir.HomeDir()
-	if home == "" {
-		return fmt.Errorf("could not find home directory")
-	}
-	kubeconfig := filepath.Join(home, ".kube", "config")
-
-	// Load kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	clientset, err := newK8sClient()
 	if err != nil {
-		return fmt.Errorf("failed to load kubeconfig: %w", err)
-	}
-
-	// Create clientset
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("failed to create clientset: %w", err)
-	}
-
-	if rawOutput {
-		return showRawK8sInfo(clientset)
+		return err
 	}
 
 	// Get nodes
@@ -94,15 +95,16 @@ func showK8sInfo(logger *log.Logger) error {
 	columns = []table.Column{
 		{Title: "Name", Width: 30},
 		{Title: "Status", Width: 10},
-		{Title: "Age", Width: 15},
+		{Title: "Age", Width: 16},
 	}
 
+	now := time.Now()
 	rows = nil
 	for _, ns := range namespaces.Items {
 		rows = append(rows, table.Row{
 			ns.Name,
 			string(ns.Status.Phase),
-			ns.CreationTimestamp.String(),
+			formatAge(ns.CreationTimestamp.Time, now),
 		})
 	}
 
@@ -112,40 +114,27 @@ func showK8sInfo(logger *log.Logger) error {
 	return nil
 }
 
-func showRawK8sInfo(clientset *kubernetes.Clientset) error {
-	// Get nodes
-	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get nodes: %w", err)
-	}
-
-	fmt.Println("Kubernetes Nodes:")
-	for _, node := range nodes.Items {
-		fmt.Printf("  Name: %s\n", node.Name)
-		fmt.Printf("    Status: %s\n", node.Status.Phase)
-		fmt.Printf("    Version: %s\n", node.Status.NodeInfo.KubeletVersion)
-		fmt.Printf("    OS: %s\n", node.Status.NodeInfo.OperatingSystem)
-		fmt.Printf("    Kernel: %s\n", node.Status.NodeInfo.KernelVersion)
-		fmt.Println()
+// newK8sClient builds a clientset from ~/.kube/config.
+func newK8sClient() (*kubernetes.Clientset, error) {
+	home := homedir.HomeDir()
+	if home == "" {
+		return nil, fmt.Errorf("could not find home directory")
 	}
+	kubeconfig := filepath.Join(home, ".kube", "config")
 
-	// Get namespaces
-	namespaces, err := clientset.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
-		return fmt.Errorf("failed to get namespaces: %w", err)
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	fmt.Println("Kubernetes Namespaces:")
-	for _, ns := range namespaces.Items {
-		fmt.Printf("  Name: %s\n", ns.Name)
-		fmt.Printf("    Status: %s\n", ns.Status.Phase)
-		fmt.Printf("    Age: %s\n", ns.CreationTimestamp.String())
-		fmt.Println()
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
-
-	return nil
+	return clientset, nil
 }
 
 func init() {
+	k8sCmd.PersistentFlags().StringVarP(&k8sNamespace, "namespace", "n", "", "namespace to list (default all namespaces)")
 	rootCmd.AddCommand(k8sCmd)
 }
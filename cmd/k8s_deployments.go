@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var k8sDeploymentsCmd = &cobra.Command{
+	Use:     "deployments",
+	Aliases: []string{"deploy"},
+	Short:   "Show deployment rollout status",
+	Long: `Show each deployment's desired, ready, updated and available replica
+counts and whether its rollout has completed. Deployments with fewer ready
+replicas than desired are highlighted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		clientset, err := newK8sClient()
+		if err != nil {
+			return err
+		}
+
+		return runWatch(cmd.Context(), func() error {
+			return showK8sDeployments(cmd.Context(), logger, clientset)
+		})
+	},
+}
+
+type deploymentInfo struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	Desired         int32  `json:"desired"`
+	Ready           int32  `json:"ready"`
+	Updated         int32  `json:"updated"`
+	Available       int32  `json:"available"`
+	RolloutComplete bool   `json:"rollout_complete"`
+}
+
+func showK8sDeployments(ctx context.Context, logger *log.Logger, clientset *kubernetes.Clientset) error {
+	logger.Debug("gathering kubernetes deployments", "namespace", k8sNamespace)
+	defer timeCollector(logger, "k8s deployments")()
+
+	deployments, err := collectK8sDeployments(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return renderStructured(deployments)
+	}
+
+	fmt.Println(titleStyle.Render("Kubernetes Deployments"))
+	columns := []table.Column{
+		{Title: "Namespace", Width: 20},
+		{Title: "Name", Width: 30},
+		{Title: "Desired", Width: 8},
+		{Title: "Ready", Width: 8},
+		{Title: "Updated", Width: 8},
+		{Title: "Available", Width: 9},
+		{Title: "Rollout", Width: 12},
+	}
+
+	var rows []table.Row
+	var degraded []int
+	for i, d := range deployments {
+		if d.Ready < d.Desired {
+			degraded = append(degraded, i)
+		}
+		rows = append(rows, table.Row{
+			d.Namespace,
+			d.Name,
+			strconv.Itoa(int(d.Desired)),
+			strconv.Itoa(int(d.Ready)),
+			strconv.Itoa(int(d.Updated)),
+			strconv.Itoa(int(d.Available)),
+			rolloutStatus(d),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, degraded)))
+
+	return nil
+}
+
+func collectK8sDeployments(ctx context.Context, clientset *kubernetes.Clientset) ([]deploymentInfo, error) {
+	list, err := clientset.AppsV1().Deployments(k8sNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	deployments := make([]deploymentInfo, 0, len(list.Items))
+	for _, d := range list.Items {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		deployments = append(deployments, deploymentInfo{
+			Namespace:       d.Namespace,
+			Name:            d.Name,
+			Desired:         desired,
+			Ready:           d.Status.ReadyReplicas,
+			Updated:         d.Status.UpdatedReplicas,
+			Available:       d.Status.AvailableReplicas,
+			RolloutComplete: rolloutComplete(d, desired),
+		})
+	}
+	return deployments, nil
+}
+
+// rolloutComplete follows kubectl rollout status: the controller has seen the
+// latest spec, every replica runs it, and no old replicas are left over.
+func rolloutComplete(d appsv1.Deployment, desired int32) bool {
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == desired &&
+		d.Status.Replicas == desired &&
+		d.Status.AvailableReplicas == desired
+}
+
+func rolloutStatus(d deploymentInfo) string {
+	if d.RolloutComplete {
+		return "complete"
+	}
+	return "progressing"
+}
+
+func init() {
+	k8sCmd.AddCommand(k8sDeploymentsCmd)
+}
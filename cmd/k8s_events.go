@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var k8sEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "List recent Kubernetes events",
+	Long: `List recent cluster events, oldest first, so the latest activity is at
+the bottom. Warning events are highlighted.
+
+This is often the quickest way to see why pods aren't starting.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		clientset, err := newK8sClient()
+		if err != nil {
+			return err
+		}
+
+		return runWatch(cmd.Context(), func() error {
+			return showK8sEvents(cmd.Context(), logger, clientset)
+		})
+	},
+}
+
+type eventInfo struct {
+	Namespace string    `json:"namespace"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Object    string    `json:"object"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+func showK8sEvents(ctx context.Context, logger *log.Logger, clientset *kubernetes.Clientset) error {
+	logger.Debug("gathering kubernetes events", "namespace", k8sNamespace)
+	defer timeCollector(logger, "k8s events")()
+
+	events, err := collectK8sEvents(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return renderStructured(events)
+	}
+
+	now := time.Now()
+
+	ageWidth := 6
+	if absoluteTime {
+		ageWidth = 16
+	}
+
+	fmt.Println(titleStyle.Render("Kubernetes Events"))
+	columns := []table.Column{
+		{Title: "Age", Width: ageWidth},
+		{Title: "Type", Width: 8},
+		{Title: "Reason", Width: 20},
+		{Title: "Object", Width: 40},
+		{Title: "Message", Width: 60},
+	}
+	// Oldest first, so ages count down while timestamps count up.
+	columns = markSorted(columns, "Age", !absoluteTime)
+
+	var rows []table.Row
+	var warnings []int
+	for i, e := range events {
+		if e.Type == corev1.EventTypeWarning {
+			warnings = append(warnings, i)
+		}
+		object := e.Object
+		if k8sNamespace == "" {
+			object = e.Namespace + "/" + object
+		}
+		rows = append(rows, table.Row{
+			formatAge(e.LastSeen, now),
+			e.Type,
+			e.Reason,
+			object,
+			e.Message,
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, warnings)))
+
+	return nil
+}
+
+// collectK8sEvents lists events in --namespace, ordered by when they were
+// last seen.
+func collectK8sEvents(ctx context.Context, clientset *kubernetes.Clientset) ([]eventInfo, error) {
+	list, err := clientset.CoreV1().Events(k8sNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	events := make([]eventInfo, 0, len(list.Items))
+	for _, e := range list.Items {
+		events = append(events, eventInfo{
+			Namespace: e.Namespace,
+			Type:      e.Type,
+			Reason:    e.Reason,
+			Object:    strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name,
+			Message:   strings.TrimSpace(e.Message),
+			Count:     e.Count,
+			LastSeen:  eventTime(e),
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.Before(events[j].LastSeen)
+	})
+	return events, nil
+}
+
+// eventTime returns when an event was last seen. Events written through the
+// events.k8s.io API only set EventTime, and some only have a creation time.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+func init() {
+	k8sCmd.AddCommand(k8sEventsCmd)
+}
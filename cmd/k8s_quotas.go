@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// quotaWarnPercent is how close to a hard limit a namespace may get before
+// it is highlighted.
+const quotaWarnPercent = 90
+
+var k8sQuotasCmd = &cobra.Command{
+	Use:   "quotas",
+	Short: "Show namespace resource quota usage",
+	Long: `Show each namespace's ResourceQuota usage against its hard limits for
+CPU, memory and pods. Quotas at or above 90% of a limit are highlighted;
+namespaces without a quota are listed as "none".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		clientset, err := newK8sClient()
+		if err != nil {
+			return err
+		}
+
+		return runWatch(cmd.Context(), func() error {
+			return showK8sQuotas(cmd.Context(), logger, clientset)
+		})
+	},
+}
+
+type namespaceQuotaInfo struct {
+	Namespace string      `json:"namespace"`
+	Quotas    []quotaInfo `json:"quotas"`
+}
+
+type quotaInfo struct {
+	Name   string      `json:"name"`
+	CPU    *quotaUsage `json:"cpu,omitempty"`
+	Memory *quotaUsage `json:"memory,omitempty"`
+	Pods   *quotaUsage `json:"pods,omitempty"`
+}
+
+type quotaUsage struct {
+	Used        string  `json:"used"`
+	Hard        string  `json:"hard"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+// nearLimit reports whether any resource is within quotaWarnPercent of its
+// hard limit.
+func (q quotaInfo) nearLimit() bool {
+	for _, u := range []*quotaUsage{q.CPU, q.Memory, q.Pods} {
+		if u != nil && u.UsedPercent >= quotaWarnPercent {
+			return true
+		}
+	}
+	return false
+}
+
+func showK8sQuotas(ctx context.Context, logger *log.Logger, clientset *kubernetes.Clientset) error {
+	logger.Debug("gathering kubernetes resource quotas", "namespace", k8sNamespace)
+	defer timeCollector(logger, "k8s quotas")()
+
+	namespaces, err := collectK8sQuotas(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return renderStructured(namespaces)
+	}
+
+	fmt.Println(titleStyle.Render("Kubernetes Resource Quotas"))
+	columns := []table.Column{
+		{Title: "Namespace", Width: 20},
+		{Title: "Quota", Width: 20},
+		{Title: "CPU", Width: 20},
+		{Title: "Memory", Width: 24},
+		{Title: "Pods", Width: 16},
+	}
+
+	var rows []table.Row
+	var near []int
+	for _, ns := range namespaces {
+		if len(ns.Quotas) == 0 {
+			rows = append(rows, table.Row{ns.Namespace, "none", "-", "-", "-"})
+			continue
+		}
+		for _, q := range ns.Quotas {
+			if q.nearLimit() {
+				near = append(near, len(rows))
+			}
+			rows = append(rows, table.Row{
+				ns.Namespace,
+				q.Name,
+				formatQuotaUsage(q.CPU),
+				formatQuotaUsage(q.Memory),
+				formatQuotaUsage(q.Pods),
+			})
+		}
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, near)))
+
+	return nil
+}
+
+// collectK8sQuotas returns the quotas of every namespace in --namespace,
+// including namespaces that have none.
+func collectK8sQuotas(ctx context.Context, clientset *kubernetes.Clientset) ([]namespaceQuotaInfo, error) {
+	var names []string
+	if k8sNamespace != "" {
+		names = []string{k8sNamespace}
+	} else {
+		list, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespaces: %w", err)
+		}
+		for _, ns := range list.Items {
+			names = append(names, ns.Name)
+		}
+	}
+
+	quotas, err := clientset.CoreV1().ResourceQuotas(k8sNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource quotas: %w", err)
+	}
+
+	byNamespace := make(map[string][]quotaInfo)
+	for _, rq := range quotas.Items {
+		byNamespace[rq.Namespace] = append(byNamespace[rq.Namespace], quotaInfo{
+			Name:   rq.Name,
+			CPU:    usageOf(rq, corev1.ResourceRequestsCPU, corev1.ResourceCPU),
+			Memory: usageOf(rq, corev1.ResourceRequestsMemory, corev1.ResourceMemory),
+			Pods:   usageOf(rq, corev1.ResourcePods),
+		})
+	}
+
+	namespaces := make([]namespaceQuotaInfo, 0, len(names))
+	for _, name := range names {
+		q := byNamespace[name]
+		if q == nil {
+			q = []quotaInfo{}
+		}
+		namespaces = append(namespaces, namespaceQuotaInfo{Namespace: name, Quotas: q})
+	}
+	return namespaces, nil
+}
+
+// usageOf returns usage of the first of resources the quota limits, or nil
+// if it limits none of them.
+func usageOf(rq corev1.ResourceQuota, resources ...corev1.ResourceName) *quotaUsage {
+	for _, name := range resources {
+		hard, ok := rq.Status.Hard[name]
+		if !ok {
+			continue
+		}
+		used := rq.Status.Used[name]
+
+		usage := &quotaUsage{Used: used.String(), Hard: hard.String()}
+		if hard.MilliValue() > 0 {
+			usage.UsedPercent = float64(used.MilliValue()) / float64(hard.MilliValue()) * 100
+		}
+		return usage
+	}
+	return nil
+}
+
+func formatQuotaUsage(u *quotaUsage) string {
+	if u == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s/%s (%.0f%%)", u.Used, u.Hard, u.UsedPercent)
+}
+
+func init() {
+	k8sCmd.AddCommand(k8sQuotasCmd)
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var k8sServicesCmd = &cobra.Command{
+	Use:     "services",
+	Aliases: []string{"svc"},
+	Short:   "List services and their endpoints",
+	Long: `List services with their type, cluster and external IPs, ports and the
+number of ready endpoints behind them. Services with no ready endpoints,
+a common cause of "connection refused", are highlighted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		clientset, err := newK8sClient()
+		if err != nil {
+			return err
+		}
+
+		return runWatch(cmd.Context(), func() error {
+			return showK8sServices(cmd.Context(), logger, clientset)
+		})
+	},
+}
+
+type serviceInfo struct {
+	Namespace      string   `json:"namespace"`
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	ClusterIP      string   `json:"cluster_ip"`
+	ExternalIPs    []string `json:"external_ips"`
+	Ports          []string `json:"ports"`
+	ReadyEndpoints int      `json:"ready_endpoints"`
+}
+
+// noEndpoints reports whether traffic to the service has nowhere to go.
+// ExternalName services resolve through DNS and never have endpoints.
+func (s serviceInfo) noEndpoints() bool {
+	return s.Type != string(corev1.ServiceTypeExternalName) && s.ReadyEndpoints == 0
+}
+
+func showK8sServices(ctx context.Context, logger *log.Logger, clientset *kubernetes.Clientset) error {
+	logger.Debug("gathering kubernetes services", "namespace", k8sNamespace)
+	defer timeCollector(logger, "k8s services")()
+
+	services, err := collectK8sServices(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return renderStructured(services)
+	}
+
+	fmt.Println(titleStyle.Render("Kubernetes Services"))
+	columns := []table.Column{
+		{Title: "Namespace", Width: 20},
+		{Title: "Name", Width: 30},
+		{Title: "Type", Width: 12},
+		{Title: "Cluster IP", Width: 15},
+		{Title: "External IP", Width: 15},
+		{Title: "Ports", Width: 20},
+		{Title: "Endpoints", Width: 9},
+	}
+
+	var rows []table.Row
+	var empty []int
+	for i, s := range services {
+		if s.noEndpoints() {
+			empty = append(empty, i)
+		}
+		rows = append(rows, table.Row{
+			s.Namespace,
+			s.Name,
+			s.Type,
+			s.ClusterIP,
+			listOrNone(s.ExternalIPs),
+			listOrNone(s.Ports),
+			strconv.Itoa(s.ReadyEndpoints),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, empty)))
+
+	return nil
+}
+
+func collectK8sServices(ctx context.Context, clientset *kubernetes.Clientset) ([]serviceInfo, error) {
+	list, err := clientset.CoreV1().Services(k8sNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	endpoints, err := clientset.CoreV1().Endpoints(k8sNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoints: %w", err)
+	}
+
+	// Endpoints objects share their service's namespace and name.
+	ready := make(map[string]int, len(endpoints.Items))
+	for _, ep := range endpoints.Items {
+		for _, subset := range ep.Subsets {
+			ready[ep.Namespace+"/"+ep.Name] += len(subset.Addresses)
+		}
+	}
+
+	services := make([]serviceInfo, 0, len(list.Items))
+	for _, svc := range list.Items {
+		services = append(services, serviceInfo{
+			Namespace:      svc.Namespace,
+			Name:           svc.Name,
+			Type:           string(svc.Spec.Type),
+			ClusterIP:      svc.Spec.ClusterIP,
+			ExternalIPs:    serviceExternalIPs(svc),
+			Ports:          servicePorts(svc),
+			ReadyEndpoints: ready[svc.Namespace+"/"+svc.Name],
+		})
+	}
+	return services, nil
+}
+
+// serviceExternalIPs returns the addresses a service is reachable on from
+// outside the cluster: explicit external IPs and load balancer ingress.
+func serviceExternalIPs(svc corev1.Service) []string {
+	ips := append([]string{}, svc.Spec.ExternalIPs...)
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		} else if ingress.Hostname != "" {
+			ips = append(ips, ingress.Hostname)
+		}
+	}
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		ips = append(ips, svc.Spec.ExternalName)
+	}
+	return ips
+}
+
+// servicePorts formats ports the way kubectl does, e.g. 80:30080/TCP.
+func servicePorts(svc corev1.Service) []string {
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		port := strconv.Itoa(int(p.Port))
+		if p.NodePort != 0 {
+			port += ":" + strconv.Itoa(int(p.NodePort))
+		}
+		ports = append(ports, port+"/"+string(p.Protocol))
+	}
+	return ports
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "<none>"
+	}
+	return strings.Join(items, ",")
+}
+
+func init() {
+	k8sCmd.AddCommand(k8sServicesCmd)
+}
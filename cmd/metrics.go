@@ -2,55 +2,64 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/log"
 	"github.com/dustin/go-humanize"
-	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
 	"github.com/spf13/cobra"
 )
 
+var showNUMA bool
+
 var metricsCmd = &cobra.Command{
 	Use:   "metrics",
 	Short: "Display detailed system metrics",
 	Long: `Display detailed system metrics using github.com/shirou/gopsutil.
 Provides information about:
-  - CPU usage and load averages
-  - Memory usage (RAM and swap)
-  - Host information and uptime`,
+  - CPU usage and load averages, and thermal throttling
+  - Memory usage (RAM and swap), and swap in/out
+  - Host information and uptime
+  - Open file descriptors against the system limit, and sockets
+  - Available entropy in the kernel random pool
+  - zram compressed memory, when zram devices are configured
+  - Per-device swap usage with --swap-devices
+  - Per-NUMA-node memory usage with --numa`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := log.FromContext(cmd.Context())
+		rates := newRateTracker()
 
-		for {
-			if err := showMetrics(logger); err != nil {
-				return err
-			}
-
-			if !watchOutput {
-				break
-			}
-			time.Sleep(2 * time.Second)
-			fmt.Print("\033[H\033[2J") // Clear screen in watch mode
-		}
-		return nil
+		return runWatch(cmd.Context(), func() error {
+			return showMetrics(logger, rates)
+		})
 	},
 }
 
-func showMetrics(logger *log.Logger) error {
+func showMetrics(logger *log.Logger, rates *rateTracker) error {
 	logger.Debug("gathering system metrics")
+	defer timeCollector(logger, "metrics")()
 
-	if rawOutput {
-		return showRawMetrics()
+	if structuredOutput() {
+		return showJSONMetrics(logger)
+	}
+
+	if pointOutput() {
+		info, err := collectMetrics(logger)
+		if err != nil {
+			return err
+		}
+		return emitPoints(logger, metricsPoints(info, time.Now()))
 	}
 
 	// CPU Usage
-	cpuPercent, err := cpu.Percent(time.Second, false)
+	cpuPercent, err := cpuTotal.Percent(time.Second)
 	if err != nil {
 		return fmt.Errorf("failed to get CPU usage: %w", err)
 	}
+	watchStats.Observe("CPU", cpuPercent[0], formatPercent)
 
 	fmt.Println(titleStyle.Render("CPU Usage"))
 	columns := []table.Column{
@@ -65,9 +74,20 @@ func showMetrics(logger *log.Logger) error {
 	t := NewTable(columns, rows)
 	fmt.Println(tableStyle.Render(t.View()))
 
+	throttle, err := readThrottling()
+	if err != nil {
+		logger.Debug("failed to check for thermal throttling", "error", err)
+	}
+	if throttle != nil && throttle.Throttling {
+		fmt.Println(warnStyle.Render(throttle.Summary()))
+		fmt.Println()
+	}
+
 	// Load Average
 	loadAvg, err := load.Avg()
 	if err == nil {
+		alerts.Threshold("load1", "load1", loadAvg.Load1, cfg.Thresholds.Load1)
+		watchStats.Observe("Load 1m", loadAvg.Load1, formatLoad)
 		fmt.Println(titleStyle.Render("Load Average"))
 		columns := []table.Column{
 			{Title: "Period", Width: 10},
@@ -87,6 +107,7 @@ func showMetrics(logger *log.Logger) error {
 	// Memory Usage
 	vmem, err := mem.VirtualMemory()
 	if err == nil {
+		watchStats.Observe("Memory", vmem.UsedPercent, formatPercent)
 		fmt.Println(titleStyle.Render("Memory Usage"))
 		columns := []table.Column{
 			{Title: "Type", Width: 10},
@@ -110,7 +131,7 @@ func showMetrics(logger *log.Logger) error {
 	if err == nil {
 		fmt.Println(titleStyle.Render("Swap Usage"))
 		columns := []table.Column{
-			{Title: "Type", Width: 10},
+			{Title: "Type", Width: 12},
 			{Title: "Value", Width: 15},
 		}
 
@@ -120,6 +141,166 @@ func showMetrics(logger *log.Logger) error {
 			{"Free", humanize.Bytes(swap.Free)},
 			{"Used%", fmt.Sprintf("%.1f%%", swap.UsedPercent)},
 		}
+		// Paging to and from swap is what hurts, more than how much of it
+		// is in use.
+		if showCounterTotals() {
+			rows = append(rows,
+				table.Row{"Swapped In", humanize.Bytes(swap.Sin)},
+				table.Row{"Swapped Out", humanize.Bytes(swap.Sout)},
+			)
+		}
+		if showCounterRates() {
+			sampled := time.Now()
+			rows = append(rows,
+				table.Row{"Swap In/s", rates.Format("swap/in", swap.Sin, sampled)},
+				table.Row{"Swap Out/s", rates.Format("swap/out", swap.Sout, sampled)},
+			)
+		}
+
+		t = NewTable(columns, rows)
+		fmt.Println(tableStyle.Render(t.View()))
+	}
+
+	// File Descriptors
+	files, err := readFiles()
+	if err != nil {
+		logger.Warn("failed to read file descriptor usage", "error", err)
+	}
+	if files != nil {
+		fmt.Println(titleStyle.Render("File Descriptors"))
+		columns := []table.Column{
+			{Title: "Type", Width: 10},
+			{Title: "Value", Width: 15},
+		}
+
+		rows := []table.Row{
+			{"Open", strconv.FormatUint(files.OpenFiles, 10)},
+			{"Max", strconv.FormatUint(files.MaxFiles, 10)},
+			{"Used%", fmt.Sprintf("%.1f%%", files.UsedPercent)},
+			{"Sockets", strconv.FormatUint(files.Sockets, 10)},
+		}
+
+		var near []int
+		if files.UsedPercent >= filesWarnPercent {
+			near = []int{0, 2}
+		}
+
+		t = NewTable(columns, rows)
+		fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, near)))
+	}
+
+	// Entropy
+	entropy, err := readEntropy()
+	if err != nil {
+		logger.Warn("failed to read entropy", "error", err)
+	}
+	if entropy != nil {
+		fmt.Println(titleStyle.Render("Entropy"))
+		columns := []table.Column{
+			{Title: "Type", Width: 10},
+			{Title: "Value", Width: 15},
+		}
+
+		rows := []table.Row{
+			{"Available", fmt.Sprintf("%d bits", entropy.AvailableBits)},
+			{"Pool Size", fmt.Sprintf("%d bits", entropy.PoolSizeBits)},
+		}
+
+		var low []int
+		if entropy.Low() {
+			low = []int{0}
+		}
+
+		t = NewTable(columns, rows)
+		fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, low)))
+	}
+
+	zram, err := readZram()
+	if err != nil {
+		logger.Warn("failed to read zram devices", "error", err)
+	}
+	if len(zram) > 0 {
+		fmt.Println(titleStyle.Render("Compressed Memory (zram)"))
+		columns := []table.Column{
+			{Title: "Device", Width: 8},
+			{Title: "Algorithm", Width: 9},
+			{Title: "Size", Width: 10},
+			{Title: "Stored", Width: 10},
+			{Title: "Compressed", Width: 10},
+			{Title: "RAM Used", Width: 10},
+			{Title: "Ratio", Width: 6},
+		}
+
+		var rows []table.Row
+		for _, z := range zram {
+			rows = append(rows, table.Row{
+				z.Device,
+				z.Algorithm,
+				humanize.Bytes(z.DiskSizeBytes),
+				humanize.Bytes(z.StoredBytes),
+				humanize.Bytes(z.CompressedBytes),
+				humanize.Bytes(z.MemUsedBytes),
+				fmt.Sprintf("%.2fx", z.Ratio),
+			})
+		}
+
+		t = NewTable(columns, rows)
+		fmt.Println(tableStyle.Render(t.View()))
+	}
+
+	devices, err := collectSwapDevices()
+	if err != nil {
+		logger.Warn("failed to read swap devices", "error", err)
+	}
+	if len(devices) > 0 {
+		fmt.Println(titleStyle.Render("Swap Devices"))
+		columns := []table.Column{
+			{Title: "Device", Width: 24},
+			{Title: "Size", Width: 10},
+			{Title: "Used", Width: 10},
+			{Title: "Free", Width: 10},
+			{Title: "Used%", Width: 8},
+		}
+
+		var rows []table.Row
+		for _, d := range devices {
+			rows = append(rows, table.Row{
+				d.Name,
+				humanize.Bytes(d.SizeBytes),
+				humanize.Bytes(d.UsedBytes),
+				humanize.Bytes(d.FreeBytes),
+				fmt.Sprintf("%.1f%%", d.UsedPercent),
+			})
+		}
+
+		t = NewTable(columns, rows)
+		fmt.Println(tableStyle.Render(t.View()))
+	}
+
+	nodes, err := collectNUMA()
+	if err != nil {
+		logger.Warn("failed to read NUMA memory", "error", err)
+	}
+	if len(nodes) > 0 {
+		fmt.Println(titleStyle.Render("NUMA Memory"))
+		columns := []table.Column{
+			{Title: "Node", Width: 6},
+			{Title: "Total", Width: 10},
+			{Title: "Used", Width: 10},
+			{Title: "Free", Width: 10},
+			{Title: "Used%", Width: 8},
+		}
+
+		var rows []table.Row
+		for _, node := range nodes {
+			rows = append(rows, table.Row{
+				strconv.Itoa(node.Node),
+				humanize.Bytes(node.TotalBytes),
+				humanize.Bytes(node.UsedBytes),
+				humanize.Bytes(node.FreeBytes),
+				fmt.Sprintf("%.1f%%", node.UsedPercent),
+			})
+		}
 
 		t = NewTable(columns, rows)
 		fmt.Println(tableStyle.Render(t.View()))
@@ -128,51 +309,241 @@ func showMetrics(logger *log.Logger) error {
 	return nil
 }
 
-func showRawMetrics() error {
-	cpuPercent, err := cpu.Percent(time.Second, false)
+type metricsInfo struct {
+	Host       string  `json:"host"`
+	CPUPercent float64 `json:"cpu_percent"`
+	// Throttle is left out where sensors or cpufreq aren't available.
+	Throttle *throttleInfo `json:"throttle,omitempty"`
+	Load     *loadInfo     `json:"load,omitempty"`
+	Memory   *memoryInfo   `json:"memory,omitempty"`
+	Swap     *memoryInfo   `json:"swap,omitempty"`
+	// SwapDevices is only filled in with --swap-devices.
+	SwapDevices []swapDeviceInfo `json:"swap_devices,omitempty"`
+	Zram        []zramInfo       `json:"zram,omitempty"`
+	Files       *filesInfo       `json:"files,omitempty"`
+	Entropy     *entropyInfo     `json:"entropy,omitempty"`
+	NUMA        []numaNodeInfo   `json:"numa,omitempty"`
+}
+
+type loadInfo struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+type memoryInfo struct {
+	TotalBytes  uint64  `json:"total_bytes"`
+	UsedBytes   uint64  `json:"used_bytes"`
+	FreeBytes   uint64  `json:"free_bytes"`
+	CachedBytes uint64  `json:"cached_bytes,omitempty"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+func showJSONMetrics(logger *log.Logger) error {
+	info, err := collectMetrics(logger)
 	if err != nil {
-		return fmt.Errorf("failed to get CPU usage: %w", err)
+		return err
 	}
-	fmt.Printf("CPU Usage: %.1f%%\n\n", cpuPercent[0])
+	return renderStructured(info)
+}
 
+// collectMetrics gathers CPU, load and memory figures for structured output.
+func collectMetrics(logger *log.Logger) (metricsInfo, error) {
+	cpuPercent, err := cpuTotal.Percent(time.Second)
+	if err != nil {
+		return metricsInfo{}, fmt.Errorf("failed to get CPU usage: %w", err)
+	}
+
+	// Stats that can't be read are left out rather than failing the sample.
 	loadAvg, err := load.Avg()
 	if err != nil {
-		fmt.Printf("Load Average: error: %v\n", err)
+		loadAvg = nil
 	} else {
-		fmt.Println("Load Average:")
-		fmt.Printf("  1 min:  %.2f\n", loadAvg.Load1)
-		fmt.Printf("  5 min:  %.2f\n", loadAvg.Load5)
-		fmt.Printf("  15 min: %.2f\n", loadAvg.Load15)
-		fmt.Println()
+		alerts.Threshold("load1", "load1", loadAvg.Load1, cfg.Thresholds.Load1)
 	}
-
 	vmem, err := mem.VirtualMemory()
 	if err != nil {
-		fmt.Printf("Memory Usage: error: %v\n", err)
-	} else {
-		fmt.Println("Memory Usage:")
-		fmt.Printf("  Total:  %s\n", humanize.Bytes(vmem.Total))
-		fmt.Printf("  Used:   %s\n", humanize.Bytes(vmem.Used))
-		fmt.Printf("  Free:   %s\n", humanize.Bytes(vmem.Free))
-		fmt.Printf("  Used%%:  %.1f%%\n", vmem.UsedPercent)
-		fmt.Printf("  Cached: %s\n", humanize.Bytes(vmem.Cached))
-		fmt.Println()
+		vmem = nil
 	}
-
 	swap, err := mem.SwapMemory()
 	if err != nil {
-		fmt.Printf("Swap Usage: error: %v\n", err)
-	} else {
-		fmt.Println("Swap Usage:")
-		fmt.Printf("  Total: %s\n", humanize.Bytes(swap.Total))
-		fmt.Printf("  Used:  %s\n", humanize.Bytes(swap.Used))
-		fmt.Printf("  Free:  %s\n", humanize.Bytes(swap.Free))
-		fmt.Printf("  Used%%: %.1f%%\n", swap.UsedPercent)
+		swap = nil
 	}
 
-	return nil
+	info := buildMetricsInfo(cpuPercent[0], loadAvg, vmem, swap)
+	info.Host = hostTag()
+
+	if info.Files, err = readFiles(); err != nil {
+		info.Files = nil
+	}
+	if info.Entropy, err = readEntropy(); err != nil {
+		info.Entropy = nil
+	}
+	if info.Throttle, err = readThrottling(); err != nil {
+		info.Throttle = nil
+	}
+	if info.SwapDevices, err = collectSwapDevices(); err != nil {
+		info.SwapDevices = nil
+	}
+	if info.Zram, err = readZram(); err != nil {
+		info.Zram = nil
+	}
+
+	if info.NUMA, err = collectNUMA(); err != nil {
+		logger.Debug("failed to read NUMA nodes", "error", err)
+		info.NUMA = nil
+	}
+
+	return info, nil
+}
+
+// buildMetricsInfo assembles structured output from gopsutil's figures.
+// Any of the stats may be nil when they couldn't be read. It doesn't touch
+// the host, so it can be fed recorded data.
+func buildMetricsInfo(cpuPercent float64, loadAvg *load.AvgStat, vmem *mem.VirtualMemoryStat, swap *mem.SwapMemoryStat) metricsInfo {
+	info := metricsInfo{CPUPercent: cpuPercent}
+
+	if loadAvg != nil {
+		info.Load = &loadInfo{
+			Load1:  loadAvg.Load1,
+			Load5:  loadAvg.Load5,
+			Load15: loadAvg.Load15,
+		}
+	}
+
+	if vmem != nil {
+		info.Memory = &memoryInfo{
+			TotalBytes:  vmem.Total,
+			UsedBytes:   vmem.Used,
+			FreeBytes:   vmem.Free,
+			CachedBytes: vmem.Cached,
+			UsedPercent: vmem.UsedPercent,
+		}
+	}
+
+	if swap != nil {
+		info.Swap = &memoryInfo{
+			TotalBytes:  swap.Total,
+			UsedBytes:   swap.Used,
+			FreeBytes:   swap.Free,
+			UsedPercent: swap.UsedPercent,
+		}
+	}
+
+	return info
+}
+
+// metricsPoints converts collected metrics to InfluxDB points.
+func metricsPoints(info metricsInfo, at time.Time) []influxPoint {
+	points := []influxPoint{{
+		measurement: "cpu",
+		fields:      []influxField{{"usage_percent", info.CPUPercent}},
+		time:        at,
+	}}
+
+	if info.Load != nil {
+		points = append(points, influxPoint{
+			measurement: "load",
+			fields: []influxField{
+				{"load1", info.Load.Load1},
+				{"load5", info.Load.Load5},
+				{"load15", info.Load.Load15},
+			},
+			time: at,
+		})
+	}
+
+	for _, m := range []struct {
+		name string
+		info *memoryInfo
+	}{{"mem", info.Memory}, {"swap", info.Swap}} {
+		if m.info == nil {
+			continue
+		}
+		points = append(points, influxPoint{
+			measurement: m.name,
+			fields: []influxField{
+				{"total_bytes", m.info.TotalBytes},
+				{"used_bytes", m.info.UsedBytes},
+				{"free_bytes", m.info.FreeBytes},
+				{"used_percent", m.info.UsedPercent},
+			},
+			time: at,
+		})
+	}
+
+	if info.Files != nil {
+		points = append(points, influxPoint{
+			measurement: "files",
+			fields: []influxField{
+				{"open", info.Files.OpenFiles},
+				{"max", info.Files.MaxFiles},
+				{"used_percent", info.Files.UsedPercent},
+				{"sockets", info.Files.Sockets},
+			},
+			time: at,
+		})
+	}
+
+	if info.Entropy != nil {
+		points = append(points, influxPoint{
+			measurement: "entropy",
+			fields: []influxField{
+				{"available_bits", info.Entropy.AvailableBits},
+				{"pool_size_bits", info.Entropy.PoolSizeBits},
+			},
+			time: at,
+		})
+	}
+
+	for _, d := range info.SwapDevices {
+		points = append(points, influxPoint{
+			measurement: "swap_device",
+			tags:        []influxTag{{"device", d.Name}},
+			fields: []influxField{
+				{"size_bytes", d.SizeBytes},
+				{"used_bytes", d.UsedBytes},
+				{"free_bytes", d.FreeBytes},
+				{"used_percent", d.UsedPercent},
+			},
+			time: at,
+		})
+	}
+
+	for _, z := range info.Zram {
+		points = append(points, influxPoint{
+			measurement: "zram",
+			tags:        []influxTag{{"device", z.Device}},
+			fields: []influxField{
+				{"disk_size_bytes", z.DiskSizeBytes},
+				{"stored_bytes", z.StoredBytes},
+				{"compressed_bytes", z.CompressedBytes},
+				{"mem_used_bytes", z.MemUsedBytes},
+				{"compression_ratio", z.Ratio},
+			},
+			time: at,
+		})
+	}
+
+	for _, node := range info.NUMA {
+		points = append(points, influxPoint{
+			measurement: "numa",
+			tags:        []influxTag{{"node", strconv.Itoa(node.Node)}},
+			fields: []influxField{
+				{"total_bytes", node.TotalBytes},
+				{"used_bytes", node.UsedBytes},
+				{"free_bytes", node.FreeBytes},
+				{"used_percent", node.UsedPercent},
+			},
+			time: at,
+		})
+	}
+
+	return points
 }
 
 func init() {
+	metricsCmd.Flags().BoolVar(&showSwapDevices, "swap-devices", false, "show usage of each swap partition, file and zram device")
+	metricsCmd.Flags().BoolVar(&showNUMA, "numa", false, "show memory usage per NUMA node on multi-node systems")
 	rootCmd.AddCommand(metricsCmd)
 }
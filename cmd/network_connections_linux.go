@@ -0,0 +1,277 @@
+//go:build linux
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/spf13/cobra"
+)
+
+var connectionsGroupBy string
+
+var networkConnectionsCmd = &cobra.Command{
+	Use:   "connections",
+	Short: "List TCP and UDP sockets and the processes that own them",
+	Long: `List TCP and UDP sockets, like netstat -tunap: protocol, local and remote
+address, state, and the owning process.
+
+On a busy server the full list is unwieldy, so --group-by counts sockets
+per remote IP (which client or backend holds 500 connections?) or per
+process (which process owns the most sockets?) instead. Grouping by remote
+IP leaves out listening and unconnected sockets, which have none.
+
+Seeing other users' processes takes root; their sockets are still listed,
+without an owner.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		switch connectionsGroupBy {
+		case "", "remote-ip", "process":
+		default:
+			return fmt.Errorf("invalid --group-by %q: must be one of remote-ip, process", connectionsGroupBy)
+		}
+
+		return runWatch(cmd.Context(), func() error {
+			return showConnections(logger)
+		})
+	},
+}
+
+// connectionInfo is a socket and its owner. Pid is 0 when the owner can't
+// be seen.
+type connectionInfo struct {
+	Protocol string `json:"protocol"`
+	Local    string `json:"local"`
+	Remote   string `json:"remote,omitempty"`
+	State    string `json:"state,omitempty"`
+	Pid      int32  `json:"pid,omitempty"`
+	Process  string `json:"process,omitempty"`
+}
+
+// connectionGroup counts the sockets sharing a remote IP or process.
+type connectionGroup struct {
+	Key    string         `json:"key"`
+	Count  int            `json:"count"`
+	States map[string]int `json:"states"`
+}
+
+func showConnections(logger *log.Logger) error {
+	logger.Debug("gathering connections")
+	defer timeCollector(logger, "connections")()
+
+	conns, err := collectConnections()
+	if err != nil {
+		return err
+	}
+
+	if connectionsGroupBy != "" {
+		return showConnectionGroups(groupConnections(conns, connectionsGroupBy))
+	}
+
+	if structuredOutput() {
+		return renderStructured(conns)
+	}
+
+	fmt.Println(titleStyle.Render("Connections"))
+	columns := []table.Column{
+		{Title: "Proto", Width: 5},
+		{Title: "Local", Width: 30},
+		{Title: "Remote", Width: 30},
+		{Title: "State", Width: 11},
+		{Title: "PID", Width: 8},
+		{Title: "Process", Width: 20},
+	}
+
+	var rows []table.Row
+	for _, c := range conns {
+		pid := "-"
+		if c.Pid != 0 {
+			pid = strconv.Itoa(int(c.Pid))
+		}
+		rows = append(rows, table.Row{
+			c.Protocol,
+			c.Local,
+			orDash(c.Remote),
+			orDash(c.State),
+			pid,
+			orDash(c.Process),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+	return nil
+}
+
+func showConnectionGroups(groups []connectionGroup) error {
+	if structuredOutput() {
+		return renderStructured(groups)
+	}
+
+	title := "Remote IP"
+	if connectionsGroupBy == "process" {
+		title = "Process"
+	}
+	fmt.Println(titleStyle.Render("Connections by " + title))
+	columns := []table.Column{
+		{Title: title, Width: 40},
+		{Title: "Count", Width: 6},
+		{Title: "States", Width: 50},
+	}
+	columns = markSorted(columns, "Count", true)
+
+	var rows []table.Row
+	for _, g := range groups {
+		rows = append(rows, table.Row{g.Key, strconv.Itoa(g.Count), formatStateCounts(g.States)})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+	return nil
+}
+
+// collectConnections lists the TCP and UDP sockets, ordered by protocol
+// and local address.
+func collectConnections() ([]connectionInfo, error) {
+	stats, err := psnet.Connections("inet")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections: %w", err)
+	}
+
+	names := make(map[int32]string)
+	conns := make([]connectionInfo, 0, len(stats))
+	for _, s := range stats {
+		c := connectionInfo{
+			Protocol: connectionProtocol(s),
+			Local:    net.JoinHostPort(s.Laddr.IP, strconv.Itoa(int(s.Laddr.Port))),
+			Pid:      s.Pid,
+		}
+		// Listening and unconnected sockets show a remote of 0.0.0.0:0.
+		if ip := net.ParseIP(s.Raddr.IP); ip != nil && !(ip.IsUnspecified() && s.Raddr.Port == 0) {
+			c.Remote = net.JoinHostPort(s.Raddr.IP, strconv.Itoa(int(s.Raddr.Port)))
+		}
+		// gopsutil reports UDP sockets with no state as "NONE".
+		if s.Status != "" && s.Status != "NONE" {
+			c.State = s.Status
+		}
+		if s.Pid != 0 {
+			name, ok := names[s.Pid]
+			if !ok {
+				if p, err := process.NewProcess(s.Pid); err == nil {
+					name, _ = p.Name()
+				}
+				names[s.Pid] = name
+			}
+			c.Process = name
+		}
+		conns = append(conns, c)
+	}
+
+	sort.SliceStable(conns, func(i, j int) bool {
+		if conns[i].Protocol != conns[j].Protocol {
+			return conns[i].Protocol < conns[j].Protocol
+		}
+		return conns[i].Local < conns[j].Local
+	})
+	return conns, nil
+}
+
+// connectionProtocol names a socket's protocol as netstat does: tcp, udp,
+// tcp6 or udp6.
+func connectionProtocol(s psnet.ConnectionStat) string {
+	proto := "tcp"
+	if s.Type == syscall.SOCK_DGRAM {
+		proto = "udp"
+	}
+	if s.Family == syscall.AF_INET6 {
+		proto += "6"
+	}
+	return proto
+}
+
+// groupConnections counts conns by remote IP or by process, most
+// connections first.
+func groupConnections(conns []connectionInfo, by string) []connectionGroup {
+	index := make(map[string]int)
+	var groups []connectionGroup
+	for _, c := range conns {
+		var key string
+		switch by {
+		case "remote-ip":
+			if c.Remote == "" {
+				continue
+			}
+			key, _, _ = net.SplitHostPort(c.Remote)
+		case "process":
+			key = "-"
+			if c.Pid != 0 {
+				key = fmt.Sprintf("%d %s", c.Pid, c.Process)
+			}
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, connectionGroup{Key: key, States: make(map[string]int)})
+		}
+		groups[i].Count++
+		if c.State != "" {
+			groups[i].States[c.State]++
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// formatStateCounts lists states by count, e.g. "ESTABLISHED 480, TIME_WAIT 20".
+func formatStateCounts(states map[string]int) string {
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if states[names[i]] != states[names[j]] {
+			return states[names[i]] > states[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, states[name])
+	}
+	return orDash(strings.Join(parts, ", "))
+}
+
+func init() {
+	networkConnectionsCmd.Flags().StringVar(&connectionsGroupBy, "group-by", "", "count sockets per remote-ip or process instead of listing them")
+	registerColumns(networkConnectionsCmd, []columnDoc{
+		{"proto", "Proto", "tcp, udp, tcp6 or udp6"},
+		{"local", "Local", "local address and port"},
+		{"remote", "Remote", "remote address and port"},
+		{"state", "State", "TCP state"},
+		{"pid", "PID", "owning process ID"},
+		{"process", "Process", "owning process name"},
+		{"group", "Remote IP/Process", "the remote IP or process (with --group-by)"},
+		{"count", "Count", "sockets in the group (with --group-by)"},
+		{"states", "States", "the group's sockets by TCP state (with --group-by)"},
+	})
+	networkCmd.AddCommand(networkConnectionsCmd)
+}
@@ -3,6 +3,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,8 +11,18 @@ import (
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/log"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	networkState          string
+	networkType           string
+	ouiFile               string
+	networkInterfacesOnly bool
+	networkRoutesOnly     bool
 )
 
 var networkCmd = &cobra.Command{
@@ -22,38 +33,62 @@ Provides information about:
   - Network interfaces and their states
   - IP addresses and CIDR ranges
   - Routing table entries
-  - Network namespaces`,
+  - Network namespaces
+
+Use --interfaces-only or --routes-only to print just one of the two tables.
+Structured output always has both.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := log.FromContext(cmd.Context())
+		rates := newNetRateTracker()
 
-		for {
-			if err := showNetworkInfo(logger); err != nil {
+		if ouiFile != "" {
+			if err := loadOUIFile(ouiFile); err != nil {
 				return err
 			}
-
-			if !watchOutput {
-				break
-			}
-			time.Sleep(2 * time.Second)
-			fmt.Print("\033[H\033[2J") // Clear screen in watch mode
 		}
-		return nil
+
+		return runWatch(cmd.Context(), func() error {
+			return showNetworkInfo(logger, rates)
+		})
 	},
 }
 
-func showNetworkInfo(logger *log.Logger) error {
+func showNetworkInfo(logger *log.Logger, rates *rateTracker) error {
 	logger.Debug("gathering network information")
+	defer timeCollector(logger, "network")()
 
 	// Get all network interfaces
 	links, err := netlink.LinkList()
 	if err != nil {
 		return fmt.Errorf("failed to get network interfaces: %w", err)
 	}
+	sampled := time.Now()
+	links = filterLinks(links, networkState, networkType)
 
-	if rawOutput {
-		return showRawNetworkInfo(links)
+	if structuredOutput() {
+		return showJSONNetworkInfo(links)
 	}
 
+	if pointOutput() {
+		info, err := collectNetwork(links)
+		if err != nil {
+			return err
+		}
+		return emitPoints(logger, networkPoints(info, rates, sampled))
+	}
+
+	if !networkRoutesOnly {
+		showInterfaceTable(logger, links, rates, sampled)
+	}
+	if !networkInterfacesOnly {
+		showRouteTable(logger)
+	}
+	return nil
+}
+
+// showInterfaceTable prints the interfaces, with byte counters and rates as
+// --since-boot and --current ask.
+func showInterfaceTable(logger *log.Logger, links []netlink.Link, rates *rateTracker, sampled time.Time) {
 	// Print interfaces table
 	fmt.Println(titleStyle.Render("Network Interfaces"))
 	
@@ -62,9 +97,22 @@ func showNetworkInfo(logger *log.Logger) error {
 		{Title: "Type", Width: 8},
 		{Title: "State", Width: 8},
 		{Title: "MAC", Width: 17},
+		{Title: "Vendor", Width: 14},
 		{Title: "MTU", Width: 5},
 		{Title: "Addresses", Width: 40},
 	}
+	if showCounterTotals() {
+		interfaceColumns = append(interfaceColumns,
+			table.Column{Title: "RX", Width: 10},
+			table.Column{Title: "TX", Width: 10},
+		)
+	}
+	if showCounterRates() {
+		interfaceColumns = append(interfaceColumns,
+			table.Column{Title: "RX/s", Width: 12},
+			table.Column{Title: "TX/s", Width: 12},
+		)
+	}
 
 	var interfaceRows []table.Row
 	for _, link := range links {
@@ -84,38 +132,55 @@ func showNetworkInfo(logger *log.Logger) error {
 			}
 		}
 
-		interfaceRows = append(interfaceRows, table.Row{
+		vendor := macVendor(attrs.HardwareAddr)
+		if vendor == "" {
+			vendor = "-"
+		}
+
+		row := table.Row{
 			attrs.Name,
 			link.Type(),
 			attrs.OperState.String(),
 			attrs.HardwareAddr.String(),
+			vendor,
 			fmt.Sprintf("%d", attrs.MTU),
 			strings.Join(addrStrs, ", "),
-		})
+		}
+		if showCounterTotals() {
+			rx, tx := linkTotals(attrs)
+			row = append(row, rx, tx)
+		}
+		if showCounterRates() {
+			rx, tx := linkRates(rates, attrs, sampled)
+			row = append(row, rx, tx)
+		}
+		interfaceRows = append(interfaceRows, row)
 	}
 
-	interfaceTable := table.New(
-		table.WithColumns(interfaceColumns),
-		table.WithRows(interfaceRows),
-		table.WithHeight(len(interfaceRows)),
-		table.WithFocused(false),
-	)
+	interfaceTable := NewTable(interfaceColumns, interfaceRows)
 	
 	fmt.Println(tableStyle.Render(interfaceTable.View()))
+}
 
+// showRouteTable prints the routing table. Failing to read it is only
+// worth a warning, since the interfaces may already be on screen.
+func showRouteTable(logger *log.Logger) {
 	// Get and print routing table
-	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	routes, err := listRoutes()
 	if err != nil {
 		logger.Warn("failed to get routing table", "error", err)
-		return nil
+		return
 	}
 
 	fmt.Println(titleStyle.Render("Routing Table"))
 
 	routeColumns := []table.Column{
+		{Title: "Family", Width: 6},
 		{Title: "Destination", Width: 20},
 		{Title: "Gateway", Width: 20},
 		{Title: "Interface", Width: 10},
+		{Title: "Source", Width: 20},
+		{Title: "Metric", Width: 6},
 		{Title: "Protocol", Width: 10},
 		{Title: "Scope", Width: 10},
 	}
@@ -139,82 +204,308 @@ func showNetworkInfo(logger *log.Logger) error {
 			}
 		}
 
+		src := "-"
+		if route.Src != nil {
+			src = route.Src.String()
+		}
+
 		routeRows = append(routeRows, table.Row{
+			routeFamilyName(route.family),
 			dst,
 			gw,
 			iface,
+			src,
+			strconv.Itoa(route.Priority),
 			strconv.Itoa(route.Protocol),
 			strconv.Itoa(int(route.Scope)),
 		})
 	}
 
-	routeTable := table.New(
-		table.WithColumns(routeColumns),
-		table.WithRows(routeRows),
-		table.WithHeight(len(routeRows)),
-		table.WithFocused(false),
-	)
+	routeTable := NewTable(routeColumns, routeRows)
 
 	fmt.Println(tableStyle.Render(routeTable.View()))
-	return nil
 }
 
-func showRawNetworkInfo(links []netlink.Link) error {
+type networkInfo struct {
+	Host       string          `json:"host"`
+	Interfaces []interfaceInfo `json:"interfaces"`
+	Routes     []routeInfo     `json:"routes"`
+}
+
+type interfaceInfo struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	State     string   `json:"state"`
+	MAC       string   `json:"mac"`
+	Vendor    string   `json:"vendor,omitempty"`
+	MTU       int      `json:"mtu"`
+	Addresses []string `json:"addresses"`
+	RxBytes   uint64   `json:"rx_bytes"`
+	TxBytes   uint64   `json:"tx_bytes"`
+}
+
+type routeInfo struct {
+	Family      string `json:"family"`
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway"`
+	Interface   string `json:"interface"`
+	Source      string `json:"source,omitempty"`
+	Metric      int    `json:"metric"`
+	Protocol    string `json:"protocol"`
+	Scope       string `json:"scope"`
+}
+
+func showJSONNetworkInfo(links []netlink.Link) error {
+	info, err := collectNetwork(links)
+	if err != nil {
+		return err
+	}
+	return renderStructured(info)
+}
+
+// collectNetwork gathers interface and route details for structured output.
+func collectNetwork(links []netlink.Link) (networkInfo, error) {
+	info := networkInfo{
+		Host:       hostTag(),
+		Interfaces: make([]interfaceInfo, 0, len(links)),
+		Routes:     make([]routeInfo, 0),
+	}
+
 	for _, link := range links {
 		attrs := link.Attrs()
-		fmt.Printf("Interface: %s\n", attrs.Name)
-		fmt.Printf("  Type: %s\n", link.Type())
-		fmt.Printf("  State: %s\n", attrs.OperState)
-		fmt.Printf("  MAC: %s\n", attrs.HardwareAddr)
-		fmt.Printf("  MTU: %d\n", attrs.MTU)
-		
+
 		addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
 		if err != nil {
-			fmt.Printf("  Addresses: error: %v\n", err)
-		} else {
-			fmt.Printf("  Addresses:\n")
-			for _, addr := range addrs {
-				fmt.Printf("    - %s\n", addr.IPNet)
-			}
+			return networkInfo{}, fmt.Errorf("failed to get addresses for %s: %w", attrs.Name, err)
+		}
+		addrStrs := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			addrStrs = append(addrStrs, addr.IPNet.String())
+		}
+
+		iface := interfaceInfo{
+			Name:      attrs.Name,
+			Type:      link.Type(),
+			State:     attrs.OperState.String(),
+			MAC:       attrs.HardwareAddr.String(),
+			Vendor:    macVendor(attrs.HardwareAddr),
+			MTU:       attrs.MTU,
+			Addresses: addrStrs,
+		}
+		if attrs.Statistics != nil {
+			iface.RxBytes = attrs.Statistics.RxBytes
+			iface.TxBytes = attrs.Statistics.TxBytes
 		}
-		fmt.Println()
+		info.Interfaces = append(info.Interfaces, iface)
 	}
 
-	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	routes, err := listRoutes()
 	if err != nil {
-		return fmt.Errorf("failed to get routing table: %w", err)
+		return networkInfo{}, err
 	}
 
-	fmt.Println("Routing Table:")
 	for _, route := range routes {
-		dst := "default"
+		r := routeInfo{
+			Family:      routeFamilyName(route.family),
+			Destination: "default",
+			Interface:   "unknown",
+			Metric:      route.Priority,
+			Protocol:    routeProtocolName(route.Protocol),
+			Scope:       routeScopeName(route.Scope),
+		}
 		if route.Dst != nil {
-			dst = route.Dst.String()
+			r.Destination = route.Dst.String()
 		}
-
-		gw := "none"
 		if route.Gw != nil {
-			gw = route.Gw.String()
+			r.Gateway = route.Gw.String()
+		}
+		if route.Src != nil {
+			r.Source = route.Src.String()
 		}
-
-		iface := "unknown"
 		if route.LinkIndex > 0 {
 			if link, err := netlink.LinkByIndex(route.LinkIndex); err == nil {
-				iface = link.Attrs().Name
+				r.Interface = link.Attrs().Name
 			}
 		}
+		info.Routes = append(info.Routes, r)
+	}
+
+	return info, nil
+}
 
-		fmt.Printf("  Destination: %s\n", dst)
-		fmt.Printf("    Gateway: %s\n", gw)
-		fmt.Printf("    Interface: %s\n", iface)
-		fmt.Printf("    Protocol: %s\n", route.Protocol)
-		fmt.Printf("    Scope: %s\n", route.Scope)
-		fmt.Println()
+// familyRoute is a route and the address family it was listed under, which
+// netlink.Route doesn't record.
+type familyRoute struct {
+	netlink.Route
+	family int
+}
+
+// listRoutes lists the IPv4 routes followed by the IPv6 ones. Each family
+// has its own default route, so a combined list would show "default" twice
+// with nothing to tell them apart.
+func listRoutes() ([]familyRoute, error) {
+	var routes []familyRoute
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		list, err := netlink.RouteList(nil, family)
+		if err != nil {
+			// IPv6 may be disabled in the kernel.
+			if family == netlink.FAMILY_V6 && errors.Is(err, unix.EAFNOSUPPORT) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get routing table: %w", err)
+		}
+		for _, r := range list {
+			routes = append(routes, familyRoute{Route: r, family: family})
+		}
 	}
+	return routes, nil
+}
 
-	return nil
+// routeFamilyName names an address family as the other tables do.
+func routeFamilyName(family int) string {
+	if family == netlink.FAMILY_V6 {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// routeProtocolName decodes the rtnetlink protocol that installed a route.
+func routeProtocolName(proto int) string {
+	switch proto {
+	case unix.RTPROT_UNSPEC:
+		return "unspec"
+	case unix.RTPROT_REDIRECT:
+		return "redirect"
+	case unix.RTPROT_KERNEL:
+		return "kernel"
+	case unix.RTPROT_BOOT:
+		return "boot"
+	case unix.RTPROT_STATIC:
+		return "static"
+	case unix.RTPROT_RA:
+		return "ra"
+	case unix.RTPROT_DHCP:
+		return "dhcp"
+	case unix.RTPROT_BIRD:
+		return "bird"
+	case unix.RTPROT_ZEBRA:
+		return "zebra"
+	default:
+		return strconv.Itoa(proto)
+	}
+}
+
+// routeScopeName decodes a route scope the way `ip route` prints it.
+func routeScopeName(scope netlink.Scope) string {
+	switch scope {
+	case netlink.SCOPE_UNIVERSE:
+		return "global"
+	case netlink.SCOPE_SITE:
+		return "site"
+	case netlink.SCOPE_LINK:
+		return "link"
+	case netlink.SCOPE_HOST:
+		return "host"
+	case netlink.SCOPE_NOWHERE:
+		return "nowhere"
+	default:
+		return strconv.Itoa(int(scope))
+	}
+}
+
+// networkPoints converts interface counters to metric points. In watch mode
+// the per-second rates are included once a previous sample exists.
+func networkPoints(info networkInfo, rates *rateTracker, at time.Time) []influxPoint {
+	points := make([]influxPoint, 0, len(info.Interfaces))
+	for _, iface := range info.Interfaces {
+		fields := []influxField{
+			{"rx_bytes", iface.RxBytes},
+			{"tx_bytes", iface.TxBytes},
+			{"mtu", iface.MTU},
+			{"up", iface.State == "up"},
+		}
+		if rx, ok := rates.Rate(iface.Name+"/rx", iface.RxBytes, at); ok {
+			fields = append(fields, influxField{"rx_bytes_per_sec", rx})
+		}
+		if tx, ok := rates.Rate(iface.Name+"/tx", iface.TxBytes, at); ok {
+			fields = append(fields, influxField{"tx_bytes_per_sec", tx})
+		}
+
+		points = append(points, influxPoint{
+			measurement: "net",
+			tags:        []influxTag{{"interface", iface.Name}},
+			fields:      fields,
+			time:        at,
+		})
+	}
+	return points
+}
+
+// filterLinks keeps the links whose operational state and link type match
+// state and linkType. An empty filter matches everything.
+func filterLinks(links []netlink.Link, state, linkType string) []netlink.Link {
+	if state == "" && linkType == "" {
+		return links
+	}
+
+	filtered := make([]netlink.Link, 0, len(links))
+	for _, link := range links {
+		if state != "" && !strings.EqualFold(link.Attrs().OperState.String(), state) {
+			continue
+		}
+		if linkType != "" && !strings.EqualFold(link.Type(), linkType) {
+			continue
+		}
+		filtered = append(filtered, link)
+	}
+	return filtered
+}
+
+// linkTotals returns the formatted bytes received and transmitted by a link
+// since it came up, usually boot.
+func linkTotals(attrs *netlink.LinkAttrs) (string, string) {
+	if attrs.Statistics == nil {
+		return "-", "-"
+	}
+	return humanize.Bytes(attrs.Statistics.RxBytes), humanize.Bytes(attrs.Statistics.TxBytes)
+}
+
+// linkRates returns the formatted receive and transmit rates for a link.
+func linkRates(rates *rateTracker, attrs *netlink.LinkAttrs, at time.Time) (string, string) {
+	if attrs.Statistics == nil {
+		return "-", "-"
+	}
+	return rates.Format(attrs.Name+"/rx", attrs.Statistics.RxBytes, at),
+		rates.Format(attrs.Name+"/tx", attrs.Statistics.TxBytes, at)
 }
 
 func init() {
+	networkCmd.Flags().StringVar(&networkState, "state", "", "only show interfaces in this operational state (e.g. up, down)")
+	networkCmd.Flags().StringVar(&ouiFile, "oui-file", "", "IEEE oui.txt to look up MAC vendors in, beyond the built-in list (e.g. /usr/share/ieee-data/oui.txt)")
+	networkCmd.Flags().StringVar(&networkType, "type", "", "only show interfaces of this link type (e.g. device, bridge, veth)")
+	networkCmd.Flags().BoolVar(&networkInterfacesOnly, "interfaces-only", false, "only show the interfaces table, not the routing table")
+	networkCmd.Flags().BoolVar(&networkRoutesOnly, "routes-only", false, "only show the routing table, not the interfaces table")
+	networkCmd.MarkFlagsMutuallyExclusive("interfaces-only", "routes-only")
+	registerColumns(networkCmd, []columnDoc{
+		{"name", "Name", "interface name"},
+		{"type", "Type", "link type (device, bridge, veth, ...)"},
+		{"state", "State", "operational state"},
+		{"mac", "MAC", "hardware address"},
+		{"vendor", "Vendor", "NIC vendor from the MAC's OUI prefix"},
+		{"mtu", "MTU", "maximum transmission unit"},
+		{"addresses", "Addresses", "assigned addresses in CIDR form"},
+		{"rx_bytes", "RX", "bytes received since boot"},
+		{"tx_bytes", "TX", "bytes transmitted since boot"},
+		{"rx_rate", "RX/s", "receive throughput (watch mode)"},
+		{"tx_rate", "TX/s", "transmit throughput (watch mode)"},
+		{"family", "Family", "address family, ipv4 or ipv6 (routing table)"},
+		{"destination", "Destination", "route destination (routing table)"},
+		{"gateway", "Gateway", "next hop"},
+		{"interface", "Interface", "outgoing interface"},
+		{"source", "Source", "preferred source address"},
+		{"metric", "Metric", "route priority; the lowest wins among routes to the same destination"},
+		{"protocol", "Protocol", "routing protocol that installed the route"},
+		{"scope", "Scope", "route scope"},
+	})
 	rootCmd.AddCommand(networkCmd)
 }
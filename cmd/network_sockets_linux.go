@@ -0,0 +1,222 @@
+//go:build linux
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+// tcpStateNames are the kernel's TCP states in the order of their numbers,
+// which /proc/net/tcp shows in hex in the "st" column.
+var tcpStateNames = []string{
+	"", "ESTABLISHED", "SYN_SENT", "SYN_RECV", "FIN_WAIT1", "FIN_WAIT2",
+	"TIME_WAIT", "CLOSE", "CLOSE_WAIT", "LAST_ACK", "LISTEN", "CLOSING",
+}
+
+var networkSocketsCmd = &cobra.Command{
+	Use:   "sockets",
+	Short: "Summarize sockets by protocol and TCP state",
+	Long: `Summarize the system's sockets, like ss -s: how many of each protocol
+are in use, TCP sockets by state, orphaned sockets and the memory TCP and
+UDP buffers take up.
+
+A climbing TIME_WAIT or orphaned count is a common sign of connection churn
+running a server out of ports or memory. Counts are read from
+/proc/net/sockstat and /proc/net/tcp, so they're cheap enough to --watch.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+		return runWatch(cmd.Context(), func() error {
+			return showSockets(logger)
+		})
+	},
+}
+
+// socketSummary is the system's socket usage. IPv4 and IPv6 are counted
+// separately since the kernel does.
+type socketSummary struct {
+	// Total is every socket, including unix and netlink ones.
+	Total     uint64            `json:"total"`
+	Protocols []socketProtocol  `json:"protocols"`
+	TCPStates map[string]uint64 `json:"tcp_states"`
+	// Orphaned are TCP sockets no process holds any more, still closing.
+	Orphaned       uint64 `json:"orphaned"`
+	TCPMemoryBytes uint64 `json:"tcp_memory_bytes"`
+	UDPMemoryBytes uint64 `json:"udp_memory_bytes"`
+}
+
+type socketProtocol struct {
+	Protocol string `json:"protocol"`
+	IPv4     uint64 `json:"ipv4"`
+	IPv6     uint64 `json:"ipv6"`
+}
+
+func showSockets(logger *log.Logger) error {
+	logger.Debug("gathering socket statistics")
+	defer timeCollector(logger, "sockets")()
+
+	summary, err := readSockets()
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return renderStructured(summary)
+	}
+
+	fmt.Println(titleStyle.Render("Sockets"))
+	fmt.Printf("Total: %d, TCP orphaned: %d, TCP memory: %s, UDP memory: %s\n",
+		summary.Total, summary.Orphaned,
+		humanize.IBytes(summary.TCPMemoryBytes), humanize.IBytes(summary.UDPMemoryBytes))
+
+	columns := []table.Column{
+		{Title: "Transport", Width: 10},
+		{Title: "Total", Width: 8},
+		{Title: "IPv4", Width: 8},
+		{Title: "IPv6", Width: 8},
+	}
+	var rows []table.Row
+	for _, p := range summary.Protocols {
+		rows = append(rows, table.Row{
+			p.Protocol,
+			strconv.FormatUint(p.IPv4+p.IPv6, 10),
+			strconv.FormatUint(p.IPv4, 10),
+			strconv.FormatUint(p.IPv6, 10),
+		})
+	}
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+
+	fmt.Println(titleStyle.Render("TCP States"))
+	columns = []table.Column{
+		{Title: "State", Width: 12},
+		{Title: "Count", Width: 8},
+	}
+	rows = nil
+	for _, state := range tcpStateNames[1:] {
+		rows = append(rows, table.Row{state, strconv.FormatUint(summary.TCPStates[state], 10)})
+	}
+	t = NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+
+	return nil
+}
+
+// readSockets reads the counts from /proc/net/sockstat{,6} and the TCP
+// states from /proc/net/tcp{,6}.
+func readSockets() (socketSummary, error) {
+	v4, err := readSockstat(sockstatPath)
+	if err != nil {
+		return socketSummary{}, err
+	}
+	// IPv6 may be disabled, leaving no sockstat6.
+	v6, _ := readSockstat(sockstatPath + "6")
+
+	// Memory is counted in pages, for IPv4 and IPv6 together.
+	page := uint64(os.Getpagesize())
+	summary := socketSummary{
+		Total:          v4["sockets"]["used"],
+		Orphaned:       v4["TCP"]["orphan"],
+		TCPMemoryBytes: v4["TCP"]["mem"] * page,
+		UDPMemoryBytes: v4["UDP"]["mem"] * page,
+		TCPStates:      make(map[string]uint64, len(tcpStateNames)-1),
+	}
+	for _, proto := range []string{"TCP", "UDP", "UDPLITE", "RAW", "FRAG"} {
+		summary.Protocols = append(summary.Protocols, socketProtocol{
+			Protocol: proto,
+			IPv4:     v4[proto]["inuse"],
+			IPv6:     v6[proto+"6"]["inuse"],
+		})
+	}
+
+	for _, state := range tcpStateNames[1:] {
+		summary.TCPStates[state] = 0
+	}
+	if err := countTCPStates("/proc/net/tcp", summary.TCPStates); err != nil {
+		return summary, err
+	}
+	_ = countTCPStates("/proc/net/tcp6", summary.TCPStates) // as above, IPv6 may be off
+	return summary, nil
+}
+
+// readSockstat parses sockstat lines such as
+//
+//	TCP: inuse 5 orphan 0 tw 0 alloc 5 mem 1
+//
+// into counters by protocol and name.
+func readSockstat(path string) (map[string]map[string]uint64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read socket counts: %w", err)
+	}
+
+	stats := make(map[string]map[string]uint64)
+	for _, line := range strings.Split(string(b), "\n") {
+		proto, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		counters := make(map[string]uint64, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			n, err := strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			counters[fields[i]] = n
+		}
+		stats[proto] = counters
+	}
+	return stats, nil
+}
+
+// countTCPStates adds up the sockets in a /proc/net/tcp table by state,
+// the fourth column:
+//
+//	sl  local_address rem_address   st tx_queue ...
+//	 0: 00000000:2005 00000000:0000 0A 00000000:00000000 ...
+func countTCPStates(path string, counts map[string]uint64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read TCP sockets: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		st, err := strconv.ParseUint(fields[3], 16, 8)
+		if err != nil || st == 0 || int(st) >= len(tcpStateNames) {
+			continue
+		}
+		counts[tcpStateNames[st]]++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read TCP sockets: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	registerColumns(networkSocketsCmd, []columnDoc{
+		{"transport", "Transport", "protocol (TCP, UDP, UDPLITE, RAW, FRAG)"},
+		{"total", "Total", "sockets in use, IPv4 and IPv6 together"},
+		{"ipv4", "IPv4", "IPv4 sockets in use"},
+		{"ipv6", "IPv6", "IPv6 sockets in use"},
+		{"state", "State", "TCP state (TCP States table)"},
+		{"count", "Count", "TCP sockets in that state"},
+	})
+	networkCmd.AddCommand(networkSocketsCmd)
+}
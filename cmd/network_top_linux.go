@@ -0,0 +1,127 @@
+//go:build linux
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"github.com/spf13/cobra"
+)
+
+var networkTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show which interfaces are moving the most traffic",
+	Long: `Sample per-interface byte counters twice, --interval apart, and list
+interfaces by throughput over that window, busiest first.
+
+With --watch the list keeps updating, each sample measured against the
+previous one.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+		rates := newNetRateTracker()
+
+		// Rates need a baseline, so take one sample up front and let the
+		// first report cover the following interval.
+		started := time.Now()
+		if _, err := sampleTalkers(rates); err != nil {
+			return err
+		}
+		if !waitInterval(cmd.Context(), started) {
+			return nil
+		}
+
+		return runWatch(cmd.Context(), func() error {
+			return showNetworkTop(logger, rates)
+		})
+	},
+}
+
+type talkerInfo struct {
+	Interface     string  `json:"interface"`
+	RxBytesPerSec float64 `json:"rx_bytes_per_sec"`
+	TxBytesPerSec float64 `json:"tx_bytes_per_sec"`
+}
+
+// Total is the combined receive and transmit rate used for ranking.
+func (t talkerInfo) Total() float64 {
+	return t.RxBytesPerSec + t.TxBytesPerSec
+}
+
+func showNetworkTop(logger *log.Logger, rates *rateTracker) error {
+	logger.Debug("sampling interface counters")
+	defer timeCollector(logger, "network top")()
+
+	talkers, err := sampleTalkers(rates)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return renderStructured(talkers)
+	}
+
+	fmt.Println(titleStyle.Render("Top Talkers"))
+	columns := []table.Column{
+		{Title: "Interface", Width: 15},
+		{Title: "RX/s", Width: 12},
+		{Title: "TX/s", Width: 12},
+		{Title: "Total/s", Width: 12},
+	}
+	columns = markSorted(columns, "Total/s", true)
+
+	var rows []table.Row
+	for _, t := range talkers {
+		rows = append(rows, table.Row{
+			t.Interface,
+			formatNetRate(t.RxBytesPerSec),
+			formatNetRate(t.TxBytesPerSec),
+			formatNetRate(t.Total()),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+
+	return nil
+}
+
+// sampleTalkers records the current per-interface counters in rates and
+// returns the interfaces with a rate available, busiest first.
+func sampleTalkers(rates *rateTracker) ([]talkerInfo, error) {
+	counters, err := psnet.IOCounters(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interface counters: %w", err)
+	}
+	at := time.Now()
+
+	talkers := make([]talkerInfo, 0, len(counters))
+	for _, c := range counters {
+		rx, ok := rates.Rate(c.Name+"/rx", c.BytesRecv, at)
+		if !ok {
+			continue
+		}
+		tx, _ := rates.Rate(c.Name+"/tx", c.BytesSent, at)
+		talkers = append(talkers, talkerInfo{
+			Interface:     c.Name,
+			RxBytesPerSec: rx,
+			TxBytesPerSec: tx,
+		})
+	}
+
+	sort.SliceStable(talkers, func(i, j int) bool {
+		if talkers[i].Total() != talkers[j].Total() {
+			return talkers[i].Total() > talkers[j].Total()
+		}
+		return talkers[i].Interface < talkers[j].Interface
+	})
+	return talkers, nil
+}
+
+func init() {
+	networkCmd.AddCommand(networkTopCmd)
+}
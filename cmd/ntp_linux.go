@@ -0,0 +1,34 @@
+//go:build linux
+
+package cmd
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// readNTPStatus asks the kernel whether the clock is synchronized, the same
+// flag timedatectl reports. A read-only adjtimex call needs no privileges.
+func readNTPStatus() (ntpStatus, error) {
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return ntpStatus{}, fmt.Errorf("failed to read clock status: %w", err)
+	}
+
+	// The offset is in microseconds, or nanoseconds with STA_NANO; the
+	// error estimates are always microseconds.
+	offset := float64(tx.Offset) / 1e3
+	if tx.Status&unix.STA_NANO != 0 {
+		offset = float64(tx.Offset) / 1e6
+	}
+
+	return ntpStatus{
+		Supported:    true,
+		Synchronized: state != unix.TIME_ERROR && tx.Status&unix.STA_UNSYNC == 0,
+		OffsetMs:     offset,
+		MaxErrorMs:   float64(tx.Maxerror) / 1e3,
+		EstErrorMs:   float64(tx.Esterror) / 1e3,
+	}, nil
+}
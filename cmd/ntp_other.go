@@ -0,0 +1,8 @@
+//go:build !linux
+
+package cmd
+
+// readNTPStatus reports NTP status as unsupported outside Linux.
+func readNTPStatus() (ntpStatus, error) {
+	return ntpStatus{}, nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// numaRoot is where Linux exposes per-node memory accounting.
+const numaRoot = "/sys/devices/system/node"
+
+type numaNodeInfo struct {
+	Node        int     `json:"node"`
+	TotalBytes  uint64  `json:"total_bytes"`
+	UsedBytes   uint64  `json:"used_bytes"`
+	FreeBytes   uint64  `json:"free_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+// readNUMANodes returns memory usage for each NUMA node, ordered by node
+// number. Systems without NUMA support report no nodes.
+func readNUMANodes() ([]numaNodeInfo, error) {
+	files, err := filepath.Glob(filepath.Join(numaRoot, "node*", "meminfo"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NUMA nodes: %w", err)
+	}
+
+	var nodes []numaNodeInfo
+	for _, file := range files {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(file)), "node"))
+		if err != nil {
+			continue
+		}
+
+		node, err := readNUMAMeminfo(file)
+		if err != nil {
+			return nil, err
+		}
+		node.Node = id
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Node < nodes[j].Node
+	})
+	return nodes, nil
+}
+
+// readNUMAMeminfo parses a node's meminfo, whose lines look like
+//
+//	Node 0 MemTotal:        6158152 kB
+func readNUMAMeminfo(path string) (numaNodeInfo, error) {
+	var node numaNodeInfo
+
+	f, err := os.Open(path)
+	if err != nil {
+		return node, fmt.Errorf("failed to read NUMA meminfo: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch fields[2] {
+		case "MemTotal:":
+			node.TotalBytes = kb * 1024
+		case "MemFree:":
+			node.FreeBytes = kb * 1024
+		case "MemUsed:":
+			node.UsedBytes = kb * 1024
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return node, fmt.Errorf("failed to read NUMA meminfo: %w", err)
+	}
+
+	if node.TotalBytes > 0 {
+		node.UsedPercent = float64(node.UsedBytes) / float64(node.TotalBytes) * 100
+	}
+	return node, nil
+}
+
+// collectNUMA returns per-node memory when --numa is set and the system has
+// more than one node; on single-node systems the breakdown adds nothing.
+func collectNUMA() ([]numaNodeInfo, error) {
+	if !showNUMA {
+		return nil, nil
+	}
+	nodes, err := readNUMANodes()
+	if err != nil || len(nodes) < 2 {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// ouiVendors maps the first three bytes of a MAC address, as upper-case hex,
+// to the NIC vendor. It's a trimmed list of common server, desktop and
+// virtual NICs; --oui-file adds the full IEEE registry.
+var ouiVendors = map[string]string{
+	// Virtual machines
+	"525400": "QEMU/KVM",
+	"080027": "VirtualBox",
+	"000569": "VMware",
+	"000C29": "VMware",
+	"001C14": "VMware",
+	"005056": "VMware",
+	"00163E": "Xen",
+	"00155D": "Hyper-V",
+	"001C42": "Parallels",
+
+	// NICs
+	"001517": "Intel",
+	"001B21": "Intel",
+	"3CFDFE": "Intel",
+	"6805CA": "Intel",
+	"90E2BA": "Intel",
+	"A0369F": "Intel",
+	"00E04C": "Realtek",
+	"000AF7": "Broadcom",
+	"001018": "Broadcom",
+	"0002C9": "Mellanox",
+	"248A07": "Mellanox",
+	"7CFE90": "Mellanox",
+	"EC0D9A": "Mellanox",
+	"000F53": "Solarflare",
+	"000743": "Chelsio",
+	"00037F": "Atheros",
+	"005043": "Marvell",
+	"00000C": "Cisco",
+	"001422": "Dell",
+
+	// Boards
+	"B827EB": "Raspberry Pi",
+	"DCA632": "Raspberry Pi",
+	"E45F01": "Raspberry Pi",
+}
+
+// macVendor returns the vendor for a hardware address, "local" for
+// locally administered addresses assigned in software (veth, bridges,
+// containers, cloud NICs), or "" when it's unknown.
+func macVendor(hw net.HardwareAddr) string {
+	if len(hw) < 3 {
+		return ""
+	}
+	if vendor, ok := ouiVendors[fmt.Sprintf("%02X%02X%02X", hw[0], hw[1], hw[2])]; ok {
+		return vendor
+	}
+	if hw[0]&0x02 != 0 {
+		return "local"
+	}
+	return ""
+}
+
+// loadOUIFile adds the vendors in an IEEE oui.txt, as shipped in
+// /usr/share/ieee-data or /usr/share/hwdata, to ouiVendors. Entries look like
+//
+//	00-1B-21   (hex)		Intel Corporate
+func loadOUIFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open OUI file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		prefix, vendor, ok := strings.Cut(scanner.Text(), "(hex)")
+		if !ok {
+			continue
+		}
+		prefix = strings.ReplaceAll(strings.TrimSpace(prefix), "-", "")
+		vendor = strings.TrimSpace(vendor)
+		if len(prefix) != 6 || vendor == "" {
+			continue
+		}
+		ouiVendors[strings.ToUpper(prefix)] = vendor
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read OUI file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/chroma/quick"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jmespath/go-jmespath"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// yamlOutput is set by -o yaml.
+	yamlOutput bool
+	// queryExpr is set by --query, and query is its compiled form.
+	queryExpr string
+	query     *jmespath.JMESPath
+)
+
+// structuredOnly is the annotation that marks a command as having no table
+// form. Such commands print YAML by default, so --query works without -o.
+const structuredOnly = "structured-only"
+
+// structuredOutput reports whether a command should print its data with
+// renderStructured rather than as tables.
+func structuredOutput() bool {
+	return jsonOutput || yamlOutput
+}
+
+// renderStructured writes v to stdout as JSON with --json, or otherwise as
+// YAML, highlighted unless color is off. YAML keys are the JSON ones, so
+// both formats describe the same fields.
+//
+// With --query, only what the JMESPath expression selects from the JSON
+// form of v is written.
+func renderStructured(v any) error {
+	if query != nil {
+		selected, err := applyQuery(v)
+		if err != nil {
+			return err
+		}
+		v = selected
+	}
+
+	if jsonOutput {
+		return printJSON(v)
+	}
+
+	b, err := marshalYAML(v)
+	if err != nil {
+		return err
+	}
+	return printYAML(b)
+}
+
+// applyQuery evaluates --query against v as it would be written in JSON, so
+// expressions use the JSON field names.
+func applyQuery(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+	var data any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode output: %w", err)
+	}
+
+	result, err := query.Search(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate --query: %w", err)
+	}
+	return result, nil
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printYAML writes YAML to stdout, highlighted for the terminal's background
+// when color is on.
+func printYAML(b []byte) error {
+	if !colorEnabled() {
+		_, err := os.Stdout.Write(b)
+		return err
+	}
+
+	style := "catppuccin-latte"
+	if lipgloss.HasDarkBackground() {
+		style = "catppuccin-frappe"
+	}
+	return quick.Highlight(os.Stdout, string(b), "yaml", "terminal256", style)
+}
+
+// marshalYAML converts v to YAML by way of its JSON encoding, which keeps
+// the json struct tags and field order. Decoding the JSON as a yaml.Node
+// rather than a map is what keeps the order.
+func marshalYAML(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert output to YAML: %w", err)
+	}
+	blockStyle(&doc)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output: %w", err)
+	}
+	return out, nil
+}
+
+// blockStyle clears the flow and quoting styles decoding JSON leaves on
+// every node, so the document is written as ordinary block YAML.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// assertJSONSchema marshals got and compares it, decoded, with want, the
+// JSON the schema documents. Comparing decoded values catches renamed,
+// missing and unexpected fields as well as wrong values.
+func assertJSONSchema(t *testing.T, got any, want string) {
+	t.Helper()
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var gotDecoded, wantDecoded any
+	if err := json.Unmarshal(b, &gotDecoded); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantDecoded); err != nil {
+		t.Fatalf("failed to unmarshal want: %v", err)
+	}
+	if !reflect.DeepEqual(gotDecoded, wantDecoded) {
+		t.Errorf("got JSON\n%s\nwant\n%s", b, want)
+	}
+}
+
+func TestBuildDiskInfoJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		partitions []disk.PartitionStat
+		usage      map[string]*disk.UsageStat
+		iostats    map[string]disk.IOCountersStat
+		want       string
+	}{
+		{
+			name: "empty",
+			want: `{"host": "", "partitions": [], "io": []}`,
+		},
+		{
+			name: "partition and device",
+			partitions: []disk.PartitionStat{
+				{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4"},
+			},
+			usage: map[string]*disk.UsageStat{
+				"/": {Total: 1000, Used: 250, Free: 750, UsedPercent: 25},
+			},
+			iostats: map[string]disk.IOCountersStat{
+				"sda": {
+					ReadBytes: 4096, WriteBytes: 8192, ReadCount: 4, WriteCount: 2,
+					ReadTime: 10, WriteTime: 6, MergedReadCount: 1, MergedWriteCount: 3,
+					IopsInProgress: 1, WeightedIO: 20,
+				},
+			},
+			want: `{
+				"host": "",
+				"partitions": [{
+					"device": "/dev/sda1", "mountpoint": "/", "fstype": "ext4",
+					"total_bytes": 1000, "used_bytes": 250, "free_bytes": 750,
+					"used_percent": 25
+				}],
+				"io": [{
+					"device": "sda",
+					"read_bytes": 4096, "write_bytes": 8192,
+					"read_count": 4, "write_count": 2,
+					"read_time_ms": 10, "write_time_ms": 6,
+					"read_latency_ms": 2.5, "write_latency_ms": 3,
+					"merged_read_count": 1, "merged_write_count": 3,
+					"iops_in_progress": 1, "weighted_io_ms": 20
+				}]
+			}`,
+		},
+		{
+			name: "partition without usage is skipped",
+			partitions: []disk.PartitionStat{
+				{Device: "/dev/sdb1", Mountpoint: "/mnt", Fstype: "xfs"},
+			},
+			iostats: map[string]disk.IOCountersStat{
+				"sdb": {},
+			},
+			want: `{
+				"host": "",
+				"partitions": [],
+				"io": [{
+					"device": "sdb",
+					"read_bytes": 0, "write_bytes": 0,
+					"read_count": 0, "write_count": 0,
+					"read_time_ms": 0, "write_time_ms": 0,
+					"read_latency_ms": 0, "write_latency_ms": 0,
+					"merged_read_count": 0, "merged_write_count": 0,
+					"iops_in_progress": 0, "weighted_io_ms": 0
+				}]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSONSchema(t, buildDiskInfo(tt.partitions, tt.usage, tt.iostats), tt.want)
+		})
+	}
+}
+
+func TestBuildMetricsInfoJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		cpuPercent float64
+		loadAvg    *load.AvgStat
+		vmem       *mem.VirtualMemoryStat
+		swap       *mem.SwapMemoryStat
+		want       string
+	}{
+		{
+			name:       "stats that couldn't be read are left out",
+			cpuPercent: 12.5,
+			want:       `{"host": "", "cpu_percent": 12.5}`,
+		},
+		{
+			name:       "all stats",
+			cpuPercent: 50,
+			loadAvg:    &load.AvgStat{Load1: 1.5, Load5: 1.25, Load15: 0.5},
+			vmem:       &mem.VirtualMemoryStat{Total: 2048, Used: 1024, Free: 512, Cached: 256, UsedPercent: 50},
+			swap:       &mem.SwapMemoryStat{Total: 1024, Used: 256, Free: 768, UsedPercent: 25},
+			want: `{
+				"host": "",
+				"cpu_percent": 50,
+				"load": {"load1": 1.5, "load5": 1.25, "load15": 0.5},
+				"memory": {
+					"total_bytes": 2048, "used_bytes": 1024, "free_bytes": 512,
+					"cached_bytes": 256, "used_percent": 50
+				},
+				"swap": {
+					"total_bytes": 1024, "used_bytes": 256, "free_bytes": 768,
+					"used_percent": 25
+				}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSONSchema(t, buildMetricsInfo(tt.cpuPercent, tt.loadAvg, tt.vmem, tt.swap), tt.want)
+		})
+	}
+}
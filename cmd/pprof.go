@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof" // registers /debug/pprof on http.DefaultServeMux
+
+	"github.com/charmbracelet/log"
+)
+
+// pprofAddr is set by the hidden --pprof flag.
+var pprofAddr string
+
+// startPprof serves net/http/pprof on addr for the life of the process, for
+// profiling systat itself, e.g.
+//
+//	go tool pprof http://localhost:6060/debug/pprof/profile?seconds=30
+func startPprof(ctx context.Context, logger *log.Logger, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to start pprof server: %w", err)
+	}
+
+	logger.Debug("serving pprof", "addr", ln.Addr().String())
+	serve(ctx, logger, "pprof", ln, http.DefaultServeMux)
+	return nil
+}
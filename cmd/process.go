@@ -1,14 +1,33 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/shirou/gopsutil/v3/process"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var (
+	processShowAffinity bool
+	processShowCgroup   bool
+	// processMaxCmdline is how much of each command line the table shows,
+	// set by --max-cmdline-length; 0 shows it all.
+	processMaxCmdline int
+	// processExcludeKernel drops kernel threads from the listing and the
+	// state counts, set by --exclude-kernel-threads.
+	processExcludeKernel bool
+	// processStructuredStates adds the state counts to structured output,
+	// set by --states.
+	processStructuredStates bool
 )
 
 var processCmd = &cobra.Command{
@@ -19,45 +38,61 @@ Provides information about:
   - Process ID and parent ID
   - Process name and command line
   - CPU and memory usage
-  - Creation time and running time`,
+  - Nice value, and CPU affinity with --affinity
+  - The cgroup or container each process runs in, with --cgroup (Linux)
+  - Creation time and running time
+
+Kernel threads can be left out with --exclude-kernel-threads.
+
+Command lines are cut to --max-cmdline-length characters in the table, the
+same with or without --raw, which only drops the color. JSON and YAML
+output always have them in full.
+
+JSON and YAML output is a list of processes. With --states it's an object
+instead, with the counts by state, as in the table's Tasks line, under
+"states" and the list under "processes".
+
+With --tui it opens an interactive monitor instead, refreshing every second,
+that sorts, filters, kills and renices processes; press ? there for keys.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := log.FromContext(cmd.Context())
 
-		for {
-			if err := showProcessInfo(logger); err != nil {
-				return err
+		if processTUI {
+			if structuredOutput() {
+				return errors.New("--tui is interactive and can't be combined with structured output")
 			}
+			return runProcessTUI(cmd.Context())
+		}
 
-			if !watchOutput {
-				break
-			}
-			time.Sleep(2 * time.Second)
-			fmt.Print("\033[H\033[2J") // Clear screen in watch mode
+		if processLimit < 0 {
+			return fmt.Errorf("--top must not be negative, got %d", processLimit)
+		}
+		if processMaxCmdline < 0 {
+			return fmt.Errorf("--max-cmdline-length must not be negative, got %d", processMaxCmdline)
 		}
-		return nil
+
+		return runWatch(cmd.Context(), func() error {
+			return showProcessInfo(logger)
+		})
 	},
 }
 
 func showProcessInfo(logger *log.Logger) error {
 	logger.Debug("gathering process information")
+	defer timeCollector(logger, "process")()
 
-	if rawOutput {
-		return showRawProcessInfo()
+	if structuredOutput() {
+		return showJSONProcessInfo()
 	}
 
-	processes, err := process.Processes()
+	processes, states, err := topProcesses()
 	if err != nil {
-		return fmt.Errorf("failed to get process list: %w", err)
+		return err
 	}
 
-	// Sort processes by CPU usage
-	sort.Slice(processes, func(i, j int) bool {
-		cpu1, _ := processes[i].CPUPercent()
-		cpu2, _ := processes[j].CPUPercent()
-		return cpu1 > cpu2
-	})
-
 	fmt.Println(titleStyle.Render("Top Processes by CPU Usage"))
+	fmt.Println(states.Summary(warnStyle))
+	fmt.Println()
 
 	columns := []table.Column{
 		{Title: "PID", Width: 8},
@@ -66,11 +101,23 @@ func showProcessInfo(logger *log.Logger) error {
 		{Title: "Memory%", Width: 8},
 		{Title: "Status", Width: 10},
 		{Title: "User", Width: 12},
-		{Title: "Command", Width: 40},
+		{Title: "Nice", Width: 4},
+		{Title: "Started", Width: 8},
+		{Title: "Runtime", Width: 8},
+		{Title: "Command", Width: processMaxCmdline},
+	}
+	columns = markSorted(columns, "CPU%", true)
+	if processShowAffinity {
+		columns = append(columns[:len(columns)-1], table.Column{Title: "Affinity", Width: 12}, columns[len(columns)-1])
 	}
+	if processShowCgroup {
+		columns = append(columns[:len(columns)-1], table.Column{Title: "Cgroup", Width: 30}, columns[len(columns)-1])
+	}
+
+	now := time.Now()
 
 	var rows []table.Row
-	for _, p := range processes[:20] { // Show top 20 processes
+	for _, p := range processes {
 		pid := p.Pid
 
 		name, err := p.Name()
@@ -102,19 +149,49 @@ func showProcessInfo(logger *log.Logger) error {
 		if err != nil {
 			cmdline = "unknown"
 		}
-		if len(cmdline) > 40 {
-			cmdline = cmdline[:37] + "..."
+		cmdline = truncateCmdline(cmdline, processMaxCmdline)
+		if processMaxCmdline == 0 {
+			command := &columns[len(columns)-1]
+			command.Width = max(command.Width, len("Command"), lipgloss.Width(cmdline))
 		}
 
-		rows = append(rows, table.Row{
+		nice := "-"
+		if n, err := processNice(p); err == nil {
+			nice = strconv.Itoa(int(n))
+		}
+
+		started, runtime := "-", "-"
+		if created, err := processStartTime(p); err == nil {
+			started = formatStartTime(created, now)
+			runtime = duration.HumanDuration(now.Sub(created))
+		}
+
+		row := table.Row{
 			fmt.Sprintf("%d", pid),
 			name,
 			fmt.Sprintf("%.1f", cpuPercent),
 			fmt.Sprintf("%.1f", memPercent),
 			status[0],
 			username,
-			cmdline,
-		})
+			nice,
+			started,
+			runtime,
+		}
+		if processShowAffinity {
+			affinity := "-"
+			if cpus, err := processAffinity(p); err == nil {
+				affinity = formatCPUList(cpus)
+			}
+			row = append(row, affinity)
+		}
+		if processShowCgroup {
+			cgroup := "-"
+			if cg, err := readProcessCgroup(pid); err == nil {
+				cgroup = cg.String()
+			}
+			row = append(row, cgroup)
+		}
+		rows = append(rows, append(row, cmdline))
 	}
 
 	t := NewTable(columns, rows)
@@ -123,66 +200,272 @@ func showProcessInfo(logger *log.Logger) error {
 	return nil
 }
 
-func showRawProcessInfo() error {
+type processInfo struct {
+	PID           int32     `json:"pid"`
+	Name          string    `json:"name"`
+	CPUPercent    float64   `json:"cpu_percent"`
+	MemoryPercent float32   `json:"memory_percent"`
+	Status        string    `json:"status"`
+	User          string    `json:"user"`
+	Nice          *int32    `json:"nice,omitempty"`
+	Affinity      []int     `json:"affinity,omitempty"`
+	StartTime     time.Time `json:"start_time"`
+	Cmdline       string    `json:"cmdline"`
+	// Cgroup is only read with --cgroup.
+	Cgroup *processCgroup `json:"cgroup,omitempty"`
+}
+
+// processList is the structured process listing with --states: a count of
+// every process by state, and the top processes.
+type processList struct {
+	States    processStates `json:"states"`
+	Processes []processInfo `json:"processes"`
+}
+
+// processStates counts processes by scheduler state, like top's Tasks line.
+type processStates struct {
+	Total    int `json:"total"`
+	Running  int `json:"running"`
+	Sleeping int `json:"sleeping"`
+	Idle     int `json:"idle"`
+	// Blocked is uninterruptible sleep (D), usually waiting on IO. A spike
+	// points at storage or NFS trouble.
+	Blocked int `json:"blocked"`
+	Stopped int `json:"stopped"`
+	Zombie  int `json:"zombie"`
+	Other   int `json:"other"`
+}
+
+func (s *processStates) add(status string) {
+	s.Total++
+	switch status {
+	case process.Running:
+		s.Running++
+	case process.Sleep:
+		s.Sleeping++
+	case process.Idle:
+		s.Idle++
+	case process.Blocked:
+		s.Blocked++
+	case process.Stop:
+		s.Stopped++
+	case process.Zombie:
+		s.Zombie++
+	default:
+		s.Other++
+	}
+}
+
+// Summary renders the counts on one line, with blocked and zombie counts in
+// warn when there are any.
+func (s processStates) Summary(warn lipgloss.Style) string {
+	flag := func(n int, label string) string {
+		text := fmt.Sprintf("%d %s", n, label)
+		if n > 0 {
+			return warn.Render(text)
+		}
+		return text
+	}
+	return fmt.Sprintf("Tasks: %d total, %d running, %d sleeping, %d idle, %s, %d stopped, %s",
+		s.Total, s.Running, s.Sleeping, s.Idle,
+		flag(s.Blocked, "blocked (D)"),
+		s.Stopped,
+		flag(s.Zombie, "zombie"),
+	)
+}
+
+func showJSONProcessInfo() error {
+	processes, err := collectProcesses()
+	if err != nil {
+		return err
+	}
+	if processStructuredStates {
+		return renderStructured(processes)
+	}
+	return renderStructured(processes.Processes)
+}
+
+// collectProcesses gathers the state counts and top processes for
+// structured output. Fields that can't be read, usually for lack of
+// permission, are left empty.
+func collectProcesses() (processList, error) {
+	processes, states, err := topProcesses()
+	if err != nil {
+		return processList{}, err
+	}
+
+	infos := make([]processInfo, 0, len(processes))
+	for _, p := range processes {
+		info := processInfo{PID: p.Pid}
+		info.Name, _ = p.Name()
+		info.CPUPercent, _ = p.CPUPercent()
+		info.MemoryPercent, _ = p.MemoryPercent()
+		if status, err := p.Status(); err == nil && len(status) > 0 {
+			info.Status = status[0]
+		}
+		info.User, _ = p.Username()
+		if nice, err := processNice(p); err == nil {
+			info.Nice = &nice
+		}
+		info.Affinity, _ = processAffinity(p)
+		if created, err := processStartTime(p); err == nil {
+			info.StartTime = created
+		}
+		info.Cmdline, _ = p.Cmdline()
+		if processShowCgroup {
+			if cg, err := readProcessCgroup(p.Pid); err == nil {
+				info.Cgroup = &cg
+			}
+		}
+		infos = append(infos, info)
+	}
+	return processList{States: states, Processes: infos}, nil
+}
+
+// processLimit is how many processes the listing shows, set by --top.
+var processLimit int
+
+// topProcesses returns the processes using the most CPU, busiest first,
+// and the state counts of every process.
+//
+// Listing is done in two passes to keep syscalls down on hosts with
+// thousands of processes: CPU usage and state, the only fields needed for
+// ranking and the summary, are read once per process, and callers then read
+// the remaining, more expensive fields (user, command line, ...) for the top
+// --top only; --exclude-kernel-threads has to read every command line. The
+// tradeoff is that a process can exit between the passes, in which case its
+// other fields show as unknown.
+func topProcesses() ([]*process.Process, processStates, error) {
+	var states processStates
 	processes, err := process.Processes()
 	if err != nil {
-		return fmt.Errorf("failed to get process list: %w", err)
+		return nil, states, fmt.Errorf("failed to get process list: %w", err)
 	}
 
-	// Sort processes by CPU usage
-	sort.Slice(processes, func(i, j int) bool {
-		cpu1, _ := processes[i].CPUPercent()
-		cpu2, _ := processes[j].CPUPercent()
-		return cpu1 > cpu2
+	type ranked struct {
+		p   *process.Process
+		cpu float64
+	}
+	candidates := make([]ranked, 0, len(processes))
+	for _, p := range processes {
+		var status string
+		if s, err := p.Status(); err == nil && len(s) > 0 {
+			status = s[0]
+		}
+		if processExcludeKernel && isKernelThread(p, status) {
+			continue
+		}
+		states.add(status)
+
+		cpuPercent, _ := p.CPUPercent()
+		candidates = append(candidates, ranked{p, cpuPercent})
+	}
+
+	// Sort processes by CPU usage, keeping PID order for ties so rows don't
+	// shuffle between refreshes
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].cpu > candidates[j].cpu
 	})
 
-	fmt.Println("Top Processes by CPU Usage:")
-	for _, p := range processes[:20] { // Show top 20 processes
-		pid := p.Pid
+	if processLimit > 0 && len(candidates) > processLimit {
+		candidates = candidates[:processLimit]
+	}
 
-		name, err := p.Name()
-		if err != nil {
-			name = "unknown"
-		}
+	top := make([]*process.Process, len(candidates))
+	for i, c := range candidates {
+		top[i] = c.p
+	}
+	return top, states, nil
+}
 
-		cpuPercent, err := p.CPUPercent()
-		if err != nil {
-			cpuPercent = 0
-		}
+// truncateCmdline shortens a command line to width characters, ending in
+// "..." when it's cut. A width of 0 leaves it whole.
+func truncateCmdline(cmdline string, width int) string {
+	r := []rune(cmdline)
+	if width == 0 || len(r) <= width {
+		return cmdline
+	}
+	if width <= 3 {
+		return string(r[:width])
+	}
+	return string(r[:width-3]) + "..."
+}
 
-		memPercent, err := p.MemoryPercent()
-		if err != nil {
-			memPercent = 0
-		}
+// isKernelThread reports whether p is a kernel thread (kworker, ksoftirqd,
+// ...), which have no command line. Zombies have lost theirs too, so they
+// are kept, as are processes whose command line can't be read.
+func isKernelThread(p *process.Process, status string) bool {
+	if status == process.Zombie {
+		return false
+	}
+	cmdline, err := p.Cmdline()
+	return err == nil && cmdline == ""
+}
 
-		status, err := p.Status()
-		if err != nil {
-			status = []string{"unknown"}
-		}
+// formatCPUList renders CPU numbers compactly as ranges, e.g. 0-3,6.
+func formatCPUList(cpus []int) string {
+	if len(cpus) == 0 {
+		return "-"
+	}
 
-		username, err := p.Username()
-		if err != nil {
-			username = "unknown"
+	var parts []string
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
 		}
-
-		cmdline, err := p.Cmdline()
-		if err != nil {
-			cmdline = "unknown"
+		if i == j {
+			parts = append(parts, strconv.Itoa(cpus[i]))
+		} else {
+			parts = append(parts, strconv.Itoa(cpus[i])+"-"+strconv.Itoa(cpus[j]))
 		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
 
-		fmt.Printf("PID: %d\n", pid)
-		fmt.Printf("  Name: %s\n", name)
-		fmt.Printf("  CPU%%: %.1f\n", cpuPercent)
-		fmt.Printf("  Memory%%: %.1f\n", memPercent)
-		fmt.Printf("  Status: %s\n", status[0])
-		fmt.Printf("  User: %s\n", username)
-		fmt.Printf("  Command: %s\n", cmdline)
-		fmt.Println()
+// processStartTime returns when p was started. gopsutil reports it in
+// milliseconds since the epoch.
+func processStartTime(p *process.Process) (time.Time, error) {
+	ms, err := p.CreateTime()
+	if err != nil {
+		return time.Time{}, err
 	}
+	return time.UnixMilli(ms), nil
+}
 
-	return nil
+// formatStartTime shows the time of day for processes started today and the
+// date for older ones, as ps does.
+func formatStartTime(t, now time.Time) string {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := now.Date()
+	if y1 == y2 && m1 == m2 && d1 == d2 {
+		return t.Format("15:04")
+	}
+	return t.Format("Jan 02")
 }
 
 func init() {
+	processCmd.Flags().IntVar(&processLimit, "top", 20, "number of processes to show, busiest first (0 for all)")
+	processCmd.Flags().BoolVar(&processShowAffinity, "affinity", false, "show the CPUs each process may run on")
+	processCmd.Flags().BoolVar(&processShowCgroup, "cgroup", false, "show the cgroup or container each process runs in (Linux)")
+	processCmd.Flags().IntVar(&processMaxCmdline, "max-cmdline-length", 40, "characters of each command line to show in the table (0 for all)")
+	processCmd.Flags().BoolVar(&processTUI, "tui", false, "open an interactive, sortable and filterable process monitor")
+	processCmd.Flags().BoolVar(&processExcludeKernel, "exclude-kernel-threads", false, "leave kernel threads out of the list and the task counts")
+	processCmd.Flags().BoolVar(&processStructuredStates, "states", false, "with --json or -o yaml, output an object with the counts by state and the process list")
+	registerColumns(processCmd, []columnDoc{
+		{"pid", "PID", "process ID"},
+		{"name", "Name", "executable name"},
+		{"cpu", "CPU%", "CPU usage since the process started"},
+		{"memory", "Memory%", "resident memory as a share of total RAM"},
+		{"status", "Status", "scheduler state (running, sleep, idle, ...)"},
+		{"user", "User", "owning user"},
+		{"nice", "Nice", "nice value, -20 to 19"},
+		{"started", "Started", "start time, or date if not started today"},
+		{"runtime", "Runtime", "time since the process started"},
+		{"affinity", "Affinity", "CPUs the process may run on (with --affinity)"},
+		{"cgroup", "Cgroup", "container name or runtime:ID, else cgroup path (with --cgroup)"},
+		{"command", "Command", "command line, truncated to --max-cmdline-length"},
+	})
 	rootCmd.AddCommand(processCmd)
 }
@@ -0,0 +1,36 @@
+//go:build linux
+
+package cmd
+
+import (
+	"github.com/shirou/gopsutil/v3/process"
+	"golang.org/x/sys/unix"
+)
+
+// processNice returns the nice value of p, from -20 to 19.
+func processNice(p *process.Process) (int32, error) {
+	// gopsutil returns the raw getpriority syscall result on Linux, which
+	// the kernel offsets to 20 - nice so it's never negative.
+	v, err := p.Nice()
+	if err != nil {
+		return 0, err
+	}
+	return 20 - v, nil
+}
+
+// processAffinity returns the CPUs p may be scheduled on. gopsutil doesn't
+// implement this on Linux, so it asks the kernel directly.
+func processAffinity(p *process.Process) ([]int, error) {
+	var set unix.CPUSet
+	if err := unix.SchedGetaffinity(int(p.Pid), &set); err != nil {
+		return nil, err
+	}
+
+	cpus := make([]int, 0, set.Count())
+	for cpu := 0; len(cpus) < set.Count(); cpu++ {
+		if set.IsSet(cpu) {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
@@ -0,0 +1,26 @@
+//go:build !linux
+
+package cmd
+
+import (
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// processNice returns the nice value of p, from -20 to 19.
+func processNice(p *process.Process) (int32, error) {
+	return p.Nice()
+}
+
+// processAffinity returns the CPUs p may be scheduled on.
+func processAffinity(p *process.Process) ([]int, error) {
+	affinity, err := p.CPUAffinity()
+	if err != nil {
+		return nil, err
+	}
+
+	cpus := make([]int, 0, len(affinity))
+	for _, cpu := range affinity {
+		cpus = append(cpus, int(cpu))
+	}
+	return cpus, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/spf13/cobra"
+)
+
+// Nice values range from -20 (highest priority) to 19 (lowest).
+const (
+	minNice = -20
+	maxNice = 19
+)
+
+var processReniceCmd = &cobra.Command{
+	Use:   "renice <pid> <value>",
+	Short: "Change the nice value of a process",
+	Long: `Set the nice value of a process, from -20 (highest priority) to 19
+(lowest). Raising the value de-prioritizes a process; lowering it requires
+root or CAP_SYS_NICE.
+
+Negative values must follow -- so they aren't read as flags:
+
+  systat process renice 1234 -- -5
+
+Asks for confirmation first unless --yes is given; --dry-run shows the
+change without making it.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		pid, err := strconv.ParseInt(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid pid %q: %w", args[0], err)
+		}
+
+		nice, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid nice value %q: %w", args[1], err)
+		}
+		if nice < minNice || nice > maxNice {
+			return fmt.Errorf("nice value %d out of range: must be between %d and %d", nice, minNice, maxNice)
+		}
+
+		p, err := process.NewProcess(int32(pid))
+		if err != nil {
+			return fmt.Errorf("failed to find process %d: %w", pid, err)
+		}
+
+		old, err := processNice(p)
+		if err != nil {
+			return fmt.Errorf("failed to read nice value of process %d: %w", pid, err)
+		}
+
+		ok, err := confirm(fmt.Sprintf("renice process %d from %d to %d", pid, old, nice))
+		if err != nil || !ok {
+			return err
+		}
+
+		if err := setProcessNice(p.Pid, nice); err != nil {
+			return fmt.Errorf("failed to renice process %d: %w", pid, err)
+		}
+
+		logger.Info("reniced process", "pid", pid, "old", old, "new", nice)
+		return nil
+	},
+}
+
+func init() {
+	registerWriteFlags(processReniceCmd)
+	processCmd.AddCommand(processReniceCmd)
+}
@@ -0,0 +1,570 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shirou/gopsutil/v3/process"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// processTUI is set by --tui.
+var processTUI bool
+
+// processSort is the column the process TUI is sorted by.
+type processSort int
+
+const (
+	sortByCPU processSort = iota
+	sortByMemory
+	sortByPID
+	sortByName
+	sortByUser
+	sortByRuntime
+)
+
+// processSortKeys are the keys that sort the process TUI by each column.
+var processSortKeys = map[string]processSort{
+	"c": sortByCPU,
+	"m": sortByMemory,
+	"p": sortByPID,
+	"n": sortByName,
+	"u": sortByUser,
+	"t": sortByRuntime,
+}
+
+// processTUIKeys are the key bindings listed in the process TUI's help
+// overlay.
+var processTUIKeys = []keyHelp{
+	{"up / down, k / j", "move the selection"},
+	{"pgup / pgdown", "page through processes"},
+	{"home / end, g / G", "jump to the first / last process"},
+	{"c m p n u t", "sort by CPU, memory, PID, name, user, runtime"},
+	{"", "(again to reverse)"},
+	{"/", "filter by name, user, command line or PID"},
+	{"x / X", "terminate (SIGTERM) / kill (SIGKILL) the selection"},
+	{"+ / -", "renice the selection by one (- needs root)"},
+	{"esc", "clear the filter, or quit"},
+	{"?", "toggle this help"},
+	{"q / ctrl+c", "quit"},
+}
+
+// processRow is one process as the TUI shows it.
+type processRow struct {
+	pid     int32
+	name    string
+	user    string
+	status  string
+	cmdline string
+	cpu     float64
+	memory  float32
+	nice    string
+	started time.Time
+}
+
+// trackedProcess keeps a process handle between refreshes, since Percent
+// measures CPU usage since the previous call on the same handle, along with
+// the fields that don't change over a process's life.
+type trackedProcess struct {
+	p       *process.Process
+	name    string
+	user    string
+	cmdline string
+	started time.Time
+	kernel  bool
+}
+
+// processSampler lists every process on each refresh. It's only used from
+// one refresh at a time, so it needs no locking.
+type processSampler struct {
+	tracked map[int32]*trackedProcess
+}
+
+func newProcessSampler() *processSampler {
+	return &processSampler{tracked: make(map[int32]*trackedProcess)}
+}
+
+// Sample returns every process with its CPU usage since the last sample,
+// and the state counts. Processes seen for the first time show no CPU
+// usage until the next sample.
+func (s *processSampler) Sample() ([]processRow, processStates, error) {
+	var states processStates
+	pids, err := process.Pids()
+	if err != nil {
+		return nil, states, fmt.Errorf("failed to get process list: %w", err)
+	}
+
+	seen := make(map[int32]bool, len(pids))
+	rows := make([]processRow, 0, len(pids))
+	for _, pid := range pids {
+		t, ok := s.tracked[pid]
+		if !ok {
+			p, err := process.NewProcess(pid)
+			if err != nil {
+				continue // exited since it was listed
+			}
+			t = &trackedProcess{p: p}
+			t.name, _ = p.Name()
+			t.user, _ = p.Username()
+			t.cmdline, _ = p.Cmdline()
+			t.started, _ = processStartTime(p)
+			s.tracked[pid] = t
+		}
+		seen[pid] = true
+
+		var status string
+		if st, err := t.p.Status(); err == nil && len(st) > 0 {
+			status = st[0]
+		}
+		if !ok {
+			t.kernel = isKernelThread(t.p, status)
+		}
+		if processExcludeKernel && t.kernel {
+			continue
+		}
+		states.add(status)
+
+		row := processRow{
+			pid:     pid,
+			name:    t.name,
+			user:    t.user,
+			status:  status,
+			cmdline: t.cmdline,
+			nice:    "-",
+			started: t.started,
+		}
+		row.cpu, _ = t.p.Percent(0)
+		row.memory, _ = t.p.MemoryPercent()
+		if n, err := processNice(t.p); err == nil {
+			row.nice = strconv.Itoa(int(n))
+		}
+		rows = append(rows, row)
+	}
+
+	for pid := range s.tracked {
+		if !seen[pid] {
+			delete(s.tracked, pid)
+		}
+	}
+	return rows, states, nil
+}
+
+type processSampleMsg struct {
+	rows   []processRow
+	states processStates
+	err    error
+}
+
+// processActionMsg reports how a kill or renice went.
+type processActionMsg struct {
+	note string
+	err  error
+}
+
+// pendingSignal is a kill waiting for the user to confirm it.
+type pendingSignal struct {
+	pid  int32
+	name string
+	kill bool
+}
+
+// processModel is the process TUI, a lighter htop-like alternative to the
+// dashboard.
+type processModel struct {
+	sampler   *processSampler
+	table     table.Model
+	rows      []processRow
+	shown     []processRow
+	states    processStates
+	err       error
+	sortBy    processSort
+	ascending bool
+	filter    string
+	filtering bool
+	confirm   *pendingSignal
+	note      string
+	noteErr   bool
+	showHelp  bool
+	width     int
+	height    int
+}
+
+func newProcessModel() processModel {
+	m := processModel{
+		sampler: newProcessSampler(),
+		sortBy:  sortByCPU,
+	}
+	m.table = table.New(
+		table.WithColumns(m.columns()),
+		table.WithStyles(interactiveTableStyles()),
+		table.WithFocused(true),
+	)
+	// Take a CPU baseline so the first refresh already has usage to show.
+	m.sampler.Sample()
+	return m
+}
+
+func (m processModel) Init() tea.Cmd {
+	return m.sampleCmd()
+}
+
+func (m processModel) sampleCmd() tea.Cmd {
+	sampler := m.sampler
+	return tea.Tick(dashboardTick, func(time.Time) tea.Msg {
+		rows, states, err := sampler.Sample()
+		return processSampleMsg{rows: rows, states: states, err: err}
+	})
+}
+
+func (m processModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.table.SetColumns(m.columns())
+		// Title, task summary, table border and footer.
+		m.table.SetHeight(max(3, m.height-7))
+		return m, nil
+
+	case processSampleMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.rows, m.states = msg.rows, msg.states
+			m.refresh()
+		}
+		return m, m.sampleCmd()
+
+	case processActionMsg:
+		m.note, m.noteErr = msg.note, msg.err != nil
+		if msg.err != nil {
+			m.note = msg.err.Error()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m processModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch {
+	case m.showHelp:
+		switch key {
+		case "q":
+			return m, tea.Quit
+		case "?", "esc":
+			m.showHelp = false
+		}
+		return m, nil
+
+	case m.confirm != nil:
+		// Anything but y cancels, so a stray key can't kill a process.
+		pending := *m.confirm
+		m.confirm = nil
+		if key == "y" {
+			return m, signalProcessCmd(pending)
+		}
+		m.note, m.noteErr = "cancelled", false
+		return m, nil
+
+	case m.filtering:
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter = ""
+		case tea.KeyBackspace:
+			if r := []rune(m.filter); len(r) > 0 {
+				m.filter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(msg.Runes)
+		}
+		m.refresh()
+		return m, nil
+	}
+
+	if sortBy, ok := processSortKeys[key]; ok {
+		if sortBy == m.sortBy {
+			m.ascending = !m.ascending
+		} else {
+			// Bigger first for the figures, A to Z and oldest PID first for
+			// the rest.
+			m.sortBy = sortBy
+			m.ascending = sortBy == sortByPID || sortBy == sortByName || sortBy == sortByUser
+		}
+		m.table.SetColumns(m.columns())
+		m.refresh()
+		return m, nil
+	}
+
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if m.filter != "" {
+			m.filter = ""
+			m.refresh()
+			return m, nil
+		}
+		return m, tea.Quit
+	case "?":
+		m.showHelp = true
+		return m, nil
+	case "/":
+		m.filtering = true
+		return m, nil
+	case "x", "X":
+		if row, ok := m.selected(); ok {
+			m.confirm = &pendingSignal{pid: row.pid, name: row.name, kill: key == "X"}
+		}
+		return m, nil
+	case "+", "-":
+		if row, ok := m.selected(); ok {
+			return m, reniceCmd(row, key == "+")
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// selected returns the process under the cursor.
+func (m processModel) selected() (processRow, bool) {
+	i := m.table.Cursor()
+	if i < 0 || i >= len(m.shown) {
+		return processRow{}, false
+	}
+	return m.shown[i], true
+}
+
+// refresh filters and sorts the latest sample into the table, keeping the
+// cursor on the same process when it's still shown.
+func (m *processModel) refresh() {
+	selected, hadSelection := m.selected()
+
+	m.shown = m.shown[:0]
+	filter := strings.ToLower(m.filter)
+	for _, r := range m.rows {
+		if filter == "" || matchesProcessFilter(r, filter) {
+			m.shown = append(m.shown, r)
+		}
+	}
+
+	sort.SliceStable(m.shown, func(i, j int) bool {
+		a, b := m.shown[i], m.shown[j]
+		if c := compareProcesses(a, b, m.sortBy); c != 0 {
+			return (c < 0) == m.ascending
+		}
+		// Ties fall back to PID so rows don't shuffle between refreshes.
+		return a.pid < b.pid
+	})
+
+	now := time.Now()
+	rows := make([]table.Row, 0, len(m.shown))
+	cursor := 0
+	for i, r := range m.shown {
+		if hadSelection && r.pid == selected.pid {
+			cursor = i
+		}
+		runtime := "-"
+		if !r.started.IsZero() {
+			runtime = duration.HumanDuration(now.Sub(r.started))
+		}
+		rows = append(rows, table.Row{
+			strconv.Itoa(int(r.pid)),
+			r.user,
+			r.nice,
+			fmt.Sprintf("%.1f", r.cpu),
+			fmt.Sprintf("%.1f", r.memory),
+			r.status,
+			runtime,
+			r.name,
+			r.cmdline,
+		})
+	}
+	m.table.SetRows(rows)
+	m.table.SetCursor(cursor)
+}
+
+// compareProcesses orders a and b by one column, as cmp.Compare does.
+// Runtime compares start times the other way round, since the process
+// started first has run longest.
+func compareProcesses(a, b processRow, by processSort) int {
+	switch by {
+	case sortByMemory:
+		return cmp.Compare(a.memory, b.memory)
+	case sortByPID:
+		return cmp.Compare(a.pid, b.pid)
+	case sortByName:
+		return cmp.Compare(a.name, b.name)
+	case sortByUser:
+		return cmp.Compare(a.user, b.user)
+	case sortByRuntime:
+		return b.started.Compare(a.started)
+	default:
+		return cmp.Compare(a.cpu, b.cpu)
+	}
+}
+
+// matchesProcessFilter reports whether a process's name, user or command
+// line contains filter, which is lower case, or its PID starts with it.
+func matchesProcessFilter(r processRow, filter string) bool {
+	return strings.Contains(strings.ToLower(r.name), filter) ||
+		strings.Contains(strings.ToLower(r.user), filter) ||
+		strings.Contains(strings.ToLower(r.cmdline), filter) ||
+		strings.HasPrefix(strconv.Itoa(int(r.pid)), filter)
+}
+
+// columns lays out the table for the terminal width, giving the command
+// line whatever is left.
+func (m processModel) columns() []table.Column {
+	columns := []table.Column{
+		{Title: "PID", Width: 8},
+		{Title: "User", Width: 10},
+		{Title: "Nice", Width: 4},
+		{Title: "CPU%", Width: 6},
+		{Title: "Mem%", Width: 6},
+		{Title: "Status", Width: 7},
+		{Title: "Runtime", Width: 8},
+		{Title: "Name", Width: 16},
+		{Title: "Command", Width: 40},
+	}
+
+	// Runtime sorts by start time, the other way round.
+	sortedBy := map[processSort]string{
+		sortByCPU: "CPU%", sortByMemory: "Mem%", sortByPID: "PID",
+		sortByName: "Name", sortByUser: "User", sortByRuntime: "Runtime",
+	}[m.sortBy]
+	columns = markSorted(columns, sortedBy, !m.ascending)
+
+	// Each cell is padded by one on either side, inside the outer border.
+	used := 2
+	for _, c := range columns[:len(columns)-1] {
+		used += c.Width + 2
+	}
+	if m.width > 0 {
+		columns[len(columns)-1].Width = max(20, m.width-used-2)
+	}
+	return columns
+}
+
+func (m processModel) View() string {
+	if m.showHelp {
+		return helpOverlay(processTUIKeys)
+	}
+
+	title := titleStyle.Copy().UnsetMarginBottom().Render("Processes")
+	if tag := hostTag(); tag != "" {
+		title += "  " + tag
+	}
+	summary := m.states.Summary(warnStyle)
+	if m.err != nil {
+		summary = warnStyle.Render(m.err.Error())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		summary,
+		tableStyle.Copy().UnsetMarginBottom().Render(m.table.View()),
+		m.footer(),
+	)
+}
+
+// footer shows the pending confirmation, the filter being typed, or the
+// result of the last action.
+func (m processModel) footer() string {
+	switch {
+	case m.confirm != nil:
+		signal := "SIGTERM"
+		if m.confirm.kill {
+			signal = "SIGKILL"
+		}
+		return warnStyle.Render(fmt.Sprintf("Send %s to %d (%s)? y/N", signal, m.confirm.pid, m.confirm.name))
+	case m.filtering:
+		return "/" + m.filter + "█"
+	}
+
+	var parts []string
+	if m.filter != "" {
+		parts = append(parts, fmt.Sprintf("filter: %q (%d of %d)", m.filter, len(m.shown), len(m.rows)))
+	}
+	if m.note != "" {
+		note := m.note
+		if m.noteErr {
+			note = warnStyle.Render(note)
+		}
+		parts = append(parts, note)
+	}
+	parts = append(parts, "? for help")
+	return strings.Join(parts, "  ")
+}
+
+// signalProcessCmd sends SIGTERM, or SIGKILL, to a confirmed process.
+func signalProcessCmd(s pendingSignal) tea.Cmd {
+	return func() tea.Msg {
+		p, err := process.NewProcess(s.pid)
+		if err != nil {
+			return processActionMsg{err: fmt.Errorf("failed to find process %d: %w", s.pid, err)}
+		}
+		signal := "SIGTERM"
+		if s.kill {
+			signal = "SIGKILL"
+			err = p.Kill()
+		} else {
+			err = p.Terminate()
+		}
+		if err != nil {
+			return processActionMsg{err: fmt.Errorf("failed to send %s to %d: %w", signal, s.pid, err)}
+		}
+		return processActionMsg{note: fmt.Sprintf("sent %s to %d (%s)", signal, s.pid, s.name)}
+	}
+}
+
+// reniceCmd moves a process's nice value one step, up to lower its priority
+// or down to raise it.
+func reniceCmd(r processRow, up bool) tea.Cmd {
+	return func() tea.Msg {
+		nice, err := strconv.Atoi(r.nice)
+		if err != nil {
+			return processActionMsg{err: fmt.Errorf("failed to read nice value of %d", r.pid)}
+		}
+		to := nice - 1
+		if up {
+			to = nice + 1
+		}
+		if to < minNice || to > maxNice {
+			return processActionMsg{err: fmt.Errorf("nice value must be between %d and %d", minNice, maxNice)}
+		}
+		if err := setProcessNice(r.pid, to); err != nil {
+			return processActionMsg{err: fmt.Errorf("failed to renice %d: %w", r.pid, err)}
+		}
+		return processActionMsg{note: fmt.Sprintf("reniced %d (%s) from %d to %d", r.pid, r.name, nice, to)}
+	}
+}
+
+// runProcessTUI runs the process TUI until it's quit or ctx is done.
+func runProcessTUI(ctx context.Context) error {
+	p := tea.NewProgram(newProcessModel(), tea.WithAltScreen(), tea.WithContext(ctx))
+	if _, err := p.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return fmt.Errorf("failed to run process TUI: %w", err)
+	}
+	return nil
+}
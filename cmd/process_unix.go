@@ -0,0 +1,19 @@
+//go:build unix
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// setProcessNice sets the nice value of pid.
+func setProcessNice(pid int32, nice int) error {
+	err := unix.Setpriority(unix.PRIO_PROCESS, int(pid), nice)
+	if errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) {
+		return fmt.Errorf("%w: lowering a nice value or renicing another user's process requires root or CAP_SYS_NICE", err)
+	}
+	return err
+}
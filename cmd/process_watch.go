@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dustin/go-humanize"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/spf13/cobra"
+)
+
+var processTree bool
+
+var processWatchCmd = &cobra.Command{
+	Use:   "watch <pid>",
+	Short: "Sample a single process over time",
+	Long: `Sample a single process every --interval and print its CPU usage,
+resident memory, thread count and open file descriptors as a time series.
+
+With --tree the figures include every descendant of the process.
+Sampling stops when the process exits.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		pid, err := strconv.ParseInt(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid pid %q: %w", args[0], err)
+		}
+
+		root, err := process.NewProcess(int32(pid))
+		if err != nil {
+			return fmt.Errorf("failed to find process %d: %w", pid, err)
+		}
+
+		// Process handles are kept between samples because Percent reports
+		// CPU usage relative to the previous call on the same handle.
+		tracked := map[int32]*process.Process{root.Pid: root}
+
+		if !structuredOutput() {
+			fmt.Println(titleStyle.Render(fmt.Sprintf("Process %d", pid)))
+			fmt.Printf("%-10s %8s %10s %8s %6s %6s\n", "TIME", "CPU%", "RSS", "THREADS", "FDS", "PROCS")
+		}
+
+		for {
+			started := time.Now()
+			running, err := root.IsRunning()
+			if err != nil || !running {
+				logger.Info("process exited", "pid", pid)
+				return nil
+			}
+
+			procs := []*process.Process{root}
+			if processTree {
+				procs = append(procs, descendants(root)...)
+			}
+
+			var sample processSample
+			sample, tracked = sampleProcesses(procs, tracked)
+			if structuredOutput() {
+				// Each sample is its own document, so YAML needs the
+				// separators to make one stream.
+				if yamlOutput {
+					fmt.Println("---")
+				}
+				if err := renderStructured(sample); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("%-10s %8.1f %10s %8d %6d %6d\n",
+					sample.Time.Format("15:04:05"),
+					sample.CPUPercent,
+					humanize.Bytes(sample.RSSBytes),
+					sample.Threads,
+					sample.FDs,
+					sample.Processes,
+				)
+			}
+
+			if !waitInterval(cmd.Context(), started) {
+				return nil
+			}
+		}
+	},
+}
+
+type processSample struct {
+	Host       string    `json:"host"`
+	Time       time.Time `json:"time"`
+	CPUPercent float64   `json:"cpu_percent"`
+	RSSBytes   uint64    `json:"rss_bytes"`
+	Threads    int32     `json:"threads"`
+	FDs        int32     `json:"fds"`
+	Processes  int       `json:"processes"`
+}
+
+// sampleProcesses sums the usage of procs. Handles are looked up in tracked
+// so CPU usage is measured since the previous sample of the same process.
+// It returns the handles to track next time: those of procs, so processes
+// that have exited are dropped.
+func sampleProcesses(procs []*process.Process, tracked map[int32]*process.Process) (processSample, map[int32]*process.Process) {
+	sample := processSample{Host: hostTag(), Time: time.Now()}
+	current := make(map[int32]*process.Process, len(procs))
+	for _, p := range procs {
+		if prev, ok := tracked[p.Pid]; ok {
+			p = prev
+		}
+		current[p.Pid] = p
+
+		if cpuPercent, err := p.Percent(0); err == nil {
+			sample.CPUPercent += cpuPercent
+		}
+		if memInfo, err := p.MemoryInfo(); err == nil {
+			sample.RSSBytes += memInfo.RSS
+		}
+		if threads, err := p.NumThreads(); err == nil {
+			sample.Threads += threads
+		}
+		if fds, err := p.NumFDs(); err == nil {
+			sample.FDs += fds
+		}
+		sample.Processes++
+	}
+	return sample, current
+}
+
+// descendants returns every child of p, recursively.
+func descendants(p *process.Process) []*process.Process {
+	children, err := p.Children()
+	if err != nil {
+		return nil
+	}
+
+	all := children
+	for _, child := range children {
+		all = append(all, descendants(child)...)
+	}
+	return all
+}
+
+func init() {
+	processWatchCmd.Flags().BoolVar(&processTree, "tree", false, "include all descendant processes")
+	processCmd.AddCommand(processWatchCmd)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package cmd
+
+import "errors"
+
+// setProcessNice sets the nice value of pid. Windows has priority classes
+// rather than nice values, so this isn't supported there.
+func setProcessNice(pid int32, nice int) error {
+	return errors.New("renice is not supported on windows")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// showCounterTotals and showCounterRates decide how tables present
+// cumulative counters: disk IO, network bytes and swap in/out. Without
+// --watch there's no interval to measure a rate over, so tables show totals
+// since boot; --watch adds per-second rates next to them. --since-boot keeps
+// only the totals and --current only the rates. Structured output always has
+// the raw counters.
+func showCounterTotals() bool {
+	return !(currentRates && watchOutput)
+}
+
+func showCounterRates() bool {
+	return watchOutput && !sinceBoot
+}
+
+// rateSample is a cumulative counter reading taken at a point in time.
+type rateSample struct {
+	value uint64
+	at    time.Time
+}
+
+// rateTracker turns cumulative counters (bytes received, bytes read, ...)
+// into per-second rates, keyed by an arbitrary name such as "eth0/rx".
+//
+// When alpha is below 1 each rate is an exponential moving average of the
+// instantaneous rates seen so far, which takes the jitter out of live views.
+type rateTracker struct {
+	alpha    float64
+	prev     map[string]rateSample
+	smoothed map[string]float64
+	format   func(float64) string
+}
+
+// newRateTracker returns a tracker configured from the --smooth flags.
+func newRateTracker() *rateTracker {
+	alpha := 1.0
+	if smoothRates {
+		alpha = smoothAlpha
+	}
+	return &rateTracker{
+		alpha:    alpha,
+		prev:     make(map[string]rateSample),
+		smoothed: make(map[string]float64),
+		format:   formatRate,
+	}
+}
+
+// newNetRateTracker returns a tracker for network counters, which Format
+// renders in bits per second when --bits is set.
+func newNetRateTracker() *rateTracker {
+	r := newRateTracker()
+	r.format = formatNetRate
+	return r
+}
+
+// Rate records value for key and returns the per-second rate since the
+// previous sample. ok is false until two samples have been recorded.
+func (r *rateTracker) Rate(key string, value uint64, at time.Time) (float64, bool) {
+	prev, seen := r.prev[key]
+	r.prev[key] = rateSample{value: value, at: at}
+	if !seen {
+		return 0, false
+	}
+
+	elapsed := at.Sub(prev.at).Seconds()
+	if elapsed <= 0 {
+		last, ok := r.smoothed[key]
+		return last, ok
+	}
+
+	// A counter that went backwards was reset (driver reload, device
+	// re-added) or wrapped. Treat it as having restarted from zero and drop
+	// the smoothing history so the old average doesn't bleed into the new one.
+	if value < prev.value {
+		rate := float64(value) / elapsed
+		r.smoothed[key] = rate
+		return rate, true
+	}
+
+	rate := float64(value-prev.value) / elapsed
+	if last, ok := r.smoothed[key]; ok && r.alpha > 0 && r.alpha < 1 {
+		rate = r.alpha*rate + (1-r.alpha)*last
+	}
+	r.smoothed[key] = rate
+	return rate, true
+}
+
+// Format records value for key like Rate and returns the rate formatted
+// for display, or "-" until a previous sample is available.
+func (r *rateTracker) Format(key string, value uint64, at time.Time) string {
+	return r.FormatRate(r.Rate(key, value, at))
+}
+
+// FormatRate formats a rate returned by Rate for display, or "-" when it
+// isn't available yet.
+func (r *rateTracker) FormatRate(rate float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return r.format(rate)
+}
+
+// formatRate renders a bytes-per-second rate for display.
+func formatRate(rate float64) string {
+	return humanize.Bytes(uint64(rate)) + "/s"
+}
+
+// formatNetRate renders a bytes-per-second network rate for display, as
+// bits per second (Mbps, Gbps, ...) with --bits since link speeds are
+// quoted in bits.
+func formatNetRate(rate float64) string {
+	if !bitRates {
+		return formatRate(rate)
+	}
+	return humanize.SIWithDigits(rate*8, 1, "bps")
+}
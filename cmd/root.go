@@ -2,16 +2,43 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/jmespath/go-jmespath"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 )
 
 var (
 	logLevel string
+	verbose  bool
 	// Common flags
-	rawOutput    bool
-	watchOutput  bool
+	rawOutput     bool
+	jsonOutput    bool
+	outputFormat  string
+	influxURL     string
+	statsdAddr    string
+	statsdPrefix  string
+	watchOutput   bool
+	watchInterval time.Duration
+	alignWatch    bool
+	smoothRates   bool
+	smoothAlpha   float64
+	bitRates      bool
+	sinceBoot     bool
+	currentRates  bool
+	notifyHook    string
+	slackWebhook  string
+	outputPath    string
+	forceColor    bool
+	// commandName is the running subcommand, e.g. "k8s events"
+	commandName string
+	outputFile  *os.File
 )
 
 var rootCmd = &cobra.Command{
@@ -27,10 +54,13 @@ Features:
   - DNS queries
   - Kubernetes information
   
-All commands support raw output (--raw) and watch mode (--watch).`,
+All commands support colorless output (--raw) and watch mode (--watch).`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		if verbose && !cmd.Flags().Changed("level") {
+			logLevel = "debug"
+		}
 		lvl, err := log.ParseLevel(logLevel)
 		if err != nil {
 			return err
@@ -38,19 +68,153 @@ All commands support raw output (--raw) and watch mode (--watch).`,
 
 		logger := log.FromContext(cmd.Context())
 		logger.SetLevel(lvl)
+		commandName = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+
+		switch outputFormat {
+		case "table", "influx":
+		case "json":
+			jsonOutput = true
+		case "yaml":
+			yamlOutput = true
+		default:
+			return fmt.Errorf("invalid --output %q: must be one of table, json, yaml, influx", outputFormat)
+		}
+		if _, ok := cmd.Annotations[structuredOnly]; ok && !jsonOutput {
+			yamlOutput = true
+		}
+		if queryExpr != "" {
+			if !structuredOutput() {
+				return fmt.Errorf("--query needs --json or -o yaml")
+			}
+			if query, err = jmespath.Compile(queryExpr); err != nil {
+				return fmt.Errorf("invalid --query: %w", err)
+			}
+		}
+		if influxURL != "" {
+			outputFormat = "influx"
+		}
+
+		if watchInterval <= 0 {
+			return fmt.Errorf("--interval must be positive, got %s", watchInterval)
+		}
+
+		if smoothAlpha <= 0 || smoothAlpha > 1 {
+			return fmt.Errorf("--smooth-alpha must be in (0, 1], got %g", smoothAlpha)
+		}
+
+		// --output-file swaps stdout itself, so every command's output
+		// follows without each one taking a writer.
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			outputFile, os.Stdout = f, f
+		}
+
+		// Raw output is the same tables without color, for logs and
+		// terminals that can't show it. Pipes and files get it too, unless
+		// --force-color asks for escapes anyway.
+		switch {
+		case rawOutput:
+			lipgloss.SetColorProfile(termenv.Ascii)
+		case forceColor:
+			lipgloss.SetColorProfile(termenv.ANSI256)
+		case !isTerminal(os.Stdout):
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+
+		explicit := cmd.Flags().Changed("config")
+		if !explicit {
+			configPath = defaultConfigPath()
+		}
+		cfg, err = loadConfig(configPath, explicit)
+		if err != nil {
+			return err
+		}
+
+		if !cmd.Flags().Changed("notify") {
+			notifyHook = cfg.Notify
+		}
+		if !cmd.Flags().Changed("slack-webhook") {
+			slackWebhook = cfg.SlackWebhook
+		}
+		if !cmd.Flags().Changed("absolute-time") {
+			absoluteTime = cfg.AbsoluteTime
+		}
+		if notifyHook != "" || slackWebhook != "" {
+			alerts = newAlerter(notifyHook, slackWebhook, logger)
+		}
+
+		if healthAddr != "" {
+			if err := startHealthServer(cmd.Context(), logger, healthAddr); err != nil {
+				return err
+			}
+		}
+		if pprofAddr != "" {
+			return startPprof(cmd.Context(), logger, pprofAddr)
+		}
 		return nil
 	},
 }
 
+// colorEnabled reports whether output is styled, as settled in
+// PersistentPreRunE from --raw, --force-color and whether stdout is a
+// terminal. It's for output that doesn't go through lipgloss.
+func colorEnabled() bool {
+	return lipgloss.ColorProfile() != termenv.Ascii
+}
+
 func ExecuteContext(ctx context.Context) error {
-	return rootCmd.ExecuteContext(ctx)
+	// Cancelling on the way out, and not just on a signal, lets servers
+	// drain before the process exits.
+	ctx, cancel := context.WithCancel(ctx)
+	err := rootCmd.ExecuteContext(ctx)
+	cancel()
+	servers.Wait()
+	alerts.Wait()
+	if outputFile != nil {
+		if cerr := outputFile.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to write output file: %w", cerr)
+		}
+	}
+	return err
 }
 
 func init() {
 	// Logging flags
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "level", "l", "info", "log level (debug, info, warn, error)")
-	
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "log collector timings (same as --level debug)")
+
+	// Developer flags
+	rootCmd.PersistentFlags().StringVar(&pprofAddr, "pprof", "", "serve net/http/pprof on this address (e.g. :6060)")
+	_ = rootCmd.PersistentFlags().MarkHidden("pprof")
+
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "config file (default $XDG_CONFIG_HOME/systat/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&notifyHook, "notify", "", "command to run, or URL to POST to, when an alert threshold is crossed")
+	rootCmd.PersistentFlags().StringVar(&slackWebhook, "slack-webhook", "", "Slack or Discord webhook URL to post alert messages to")
+	rootCmd.PersistentFlags().StringVar(&healthAddr, "listen", "", "serve a /healthz endpoint on this address (e.g. :8080)")
+
 	// Output format flags
-	rootCmd.PersistentFlags().BoolVar(&rawOutput, "raw", false, "output without styling")
+	rootCmd.PersistentFlags().BoolVar(&rawOutput, "raw", false, "output tables without color")
+	rootCmd.PersistentFlags().BoolVar(&forceColor, "force-color", false, "keep color when output isn't a terminal")
+	rootCmd.PersistentFlags().BoolVar(&absoluteTime, "absolute-time", false, "show timestamps instead of ages (5m, 3d) in age columns")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output as JSON (same as -o json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (table, json, yaml, influx)")
+	rootCmd.PersistentFlags().StringVar(&queryExpr, "query", "", "JMESPath expression selecting what to print from JSON or YAML output")
+	rootCmd.PersistentFlags().StringVar(&outputPath, "output-file", "", "write output to this file, without color, instead of stdout")
+	rootCmd.PersistentFlags().StringVar(&influxURL, "influx-url", "", "write InfluxDB line protocol to this URL instead of stdout")
+	rootCmd.PersistentFlags().StringVar(&statsdAddr, "statsd", "", "send gauges to this StatsD host:port over UDP instead of printing")
+	rootCmd.PersistentFlags().StringVar(&hostTagOverride, "host-tag", "", "identify this host in JSON, InfluxDB and StatsD output (default hostname)")
+	rootCmd.PersistentFlags().BoolVar(&hostTagFQDN, "fqdn", false, "use the fully qualified domain name as the default host tag")
+	rootCmd.PersistentFlags().StringVar(&statsdPrefix, "statsd-prefix", "systat", "prefix for StatsD metric names")
 	rootCmd.PersistentFlags().BoolVar(&watchOutput, "watch", false, "continuously watch for changes")
+	rootCmd.PersistentFlags().DurationVar(&watchInterval, "interval", 2*time.Second, "refresh interval in watch mode")
+	rootCmd.PersistentFlags().BoolVar(&alignWatch, "align", false, "align watch-mode samples to wall-clock multiples of --interval")
+	rootCmd.PersistentFlags().BoolVar(&sinceBoot, "since-boot", false, "show cumulative counters (disk IO, network bytes, swap in/out) only as totals since boot, even with --watch")
+	rootCmd.PersistentFlags().BoolVar(&currentRates, "current", false, "show cumulative counters only as per-second rates over the last --interval (with --watch)")
+	rootCmd.MarkFlagsMutuallyExclusive("since-boot", "current")
+	rootCmd.PersistentFlags().BoolVar(&bitRates, "bits", false, "show network rates in bits per second (Mbps) instead of bytes")
+	rootCmd.PersistentFlags().BoolVar(&smoothRates, "smooth", false, "smooth per-second rates with an exponential moving average")
+	rootCmd.PersistentFlags().Float64Var(&smoothAlpha, "smooth-alpha", 0.3, "weight of the newest sample when --smooth is set (0 < alpha <= 1)")
 }
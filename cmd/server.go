@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// shutdownTimeout is how long servers get to finish in-flight requests when
+// systat exits.
+const shutdownTimeout = 5 * time.Second
+
+// servers tracks the running servers so ExecuteContext can wait for them to
+// shut down before exiting.
+var servers sync.WaitGroup
+
+// serve runs handler on ln until ctx is done, then shuts the server down,
+// giving in-flight requests up to shutdownTimeout to finish.
+func serve(ctx context.Context, logger *log.Logger, name string, ln net.Listener, handler http.Handler) {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	servers.Add(1)
+	go func() {
+		defer servers.Done()
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("server did not shut down cleanly", "server", name, "error", err)
+			_ = srv.Close()
+		}
+	}()
+
+	go func() {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("server stopped", "server", name, "error", err)
+		}
+	}()
+}
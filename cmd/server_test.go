@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestServeShutsDownWithinGracePeriod(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The handler holds its request open across the shutdown, which has to
+	// let it finish rather than cut it off.
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serve(ctx, log.New(io.Discard), "test", ln, handler)
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		responses <- response{string(b), err}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(shutdownTimeout):
+		t.Fatal("request never reached the handler")
+	}
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		servers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		t.Fatalf("server didn't stop within %s", shutdownTimeout)
+	}
+
+	r := <-responses
+	if r.err != nil || r.body != "ok" {
+		t.Errorf("in-flight request got %q, %v; want it to finish with \"ok\"", r.body, r.err)
+	}
+}
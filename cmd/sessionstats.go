@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// sessionStat is the running minimum, maximum and mean of one metric.
+type sessionStat struct {
+	format   func(float64) string
+	min, max float64
+	sum      float64
+	count    int
+}
+
+// sessionStats aggregates key metrics over a watch session, so the peak CPU
+// or worst check latency seen so far stays visible after the moment has
+// passed. Metrics are kept in the order they were first observed.
+type sessionStats struct {
+	since time.Time
+	names []string
+	stats map[string]*sessionStat
+}
+
+// watchStats is the session of the running watch-mode command, shown as a
+// footer under each frame by runWatch.
+var watchStats = newSessionStats()
+
+func newSessionStats() *sessionStats {
+	return &sessionStats{since: time.Now(), stats: make(map[string]*sessionStat)}
+}
+
+// Observe records a sample of the named metric, which format renders.
+func (s *sessionStats) Observe(name string, v float64, format func(float64) string) {
+	st, ok := s.stats[name]
+	if !ok {
+		st = &sessionStat{format: format, min: v, max: v}
+		s.stats[name] = st
+		s.names = append(s.names, name)
+	}
+	st.min = min(st.min, v)
+	st.max = max(st.max, v)
+	st.sum += v
+	st.count++
+}
+
+// Reset forgets everything observed and starts a new session.
+func (s *sessionStats) Reset() {
+	s.since = time.Now()
+	s.names = nil
+	clear(s.stats)
+}
+
+// Empty reports whether nothing has been observed this session.
+func (s *sessionStats) Empty() bool {
+	return len(s.names) == 0
+}
+
+// View renders the session as a table of each metric's min, average and max.
+func (s *sessionStats) View() string {
+	columns := []table.Column{
+		{Title: "Metric", Width: 30},
+		{Title: "Min", Width: 10},
+		{Title: "Avg", Width: 10},
+		{Title: "Max", Width: 10},
+		{Title: "Samples", Width: 7},
+	}
+
+	var rows []table.Row
+	for _, name := range s.names {
+		st := s.stats[name]
+		rows = append(rows, table.Row{
+			name,
+			st.format(st.min),
+			st.format(st.mean()),
+			st.format(st.max),
+			fmt.Sprintf("%d", st.count),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	return titleStyle.Render("Session ("+s.age()+")") + "\n" + tableStyle.Render(t.View())
+}
+
+// Line renders the session on one line, as the min, average and max of
+// each metric, e.g. "CPU 2.0%/5.1%/80.0%".
+func (s *sessionStats) Line() string {
+	parts := make([]string, 0, len(s.names))
+	for _, name := range s.names {
+		st := s.stats[name]
+		parts = append(parts, fmt.Sprintf("%s %s/%s/%s",
+			name, st.format(st.min), st.format(st.mean()), st.format(st.max)))
+	}
+	return "Session " + s.age() + " (min/avg/max): " + strings.Join(parts, "  ")
+}
+
+func (st *sessionStat) mean() float64 {
+	return st.sum / float64(st.count)
+}
+
+// age is how long the session has been running, to the second.
+func (s *sessionStats) age() string {
+	return time.Since(s.since).Truncate(time.Second).String()
+}
+
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v)
+}
+
+func formatLoad(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
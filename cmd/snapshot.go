@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	psnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// dashboardSnapshot is everything the dashboard is showing at one instant,
+// written out with "y" to attach to a bug report. The stats are gopsutil's
+// own structs, so the shape matches what the collectors returned.
+type dashboardSnapshot struct {
+	Host        string                          `json:"host"`
+	TakenAt     time.Time                       `json:"taken_at"`
+	LastUpdate  time.Time                       `json:"last_update"`
+	View        string                          `json:"view"`
+	CPUPercents []float64                       `json:"cpu_percents"`
+	CPUMHz      map[int]float64                 `json:"cpu_mhz,omitempty"`
+	Load        *load.AvgStat                   `json:"load,omitempty"`
+	Memory      *mem.VirtualMemoryStat          `json:"memory,omitempty"`
+	Swap        *mem.SwapMemoryStat             `json:"swap,omitempty"`
+	DiskIO      map[string]disk.IOCountersStat  `json:"disk_io"`
+	Partitions  []disk.PartitionStat            `json:"partitions"`
+	DiskUsage   map[string]*disk.UsageStat      `json:"disk_usage"`
+	Network     map[string]psnet.IOCountersStat `json:"network"`
+	NetRates    map[string]snapshotRates        `json:"network_rates"`
+	Checks      []snapshotCheck                 `json:"checks"`
+	Namespaces  []snapshotNamespace             `json:"namespaces"`
+	Errors      []snapshotError                 `json:"errors"`
+}
+
+// snapshotRates are the rates as displayed, smoothing and units included.
+type snapshotRates struct {
+	RX string `json:"rx"`
+	TX string `json:"tx"`
+}
+
+type snapshotCheck struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	OK     bool   `json:"ok"`
+	// Checked is false until the first result, when OK means nothing yet.
+	Checked bool `json:"checked"`
+}
+
+type snapshotNamespace struct {
+	Name    string    `json:"name"`
+	Phase   string    `json:"phase"`
+	Created time.Time `json:"created"`
+}
+
+type snapshotError struct {
+	Section   string `json:"section"`
+	Collector string `json:"collector"`
+	Error     string `json:"error"`
+}
+
+// snapshot captures the model's current state.
+func (m model) snapshot() dashboardSnapshot {
+	s := dashboardSnapshot{
+		Host:        hostTag(),
+		TakenAt:     time.Now(),
+		LastUpdate:  m.lastStatsAt,
+		View:        [...]string{"dashboard", "network detail", "errors"}[m.currentView],
+		CPUPercents: m.cpuPercents,
+		CPUMHz:      m.cpuFreqs,
+		Load:        m.loadAvg,
+		Memory:      m.memory,
+		Swap:        m.swap,
+		DiskIO:      m.diskStats,
+		Partitions:  m.diskPartitions,
+		DiskUsage:   m.diskUsage,
+		Network:     m.netStats,
+		NetRates:    make(map[string]snapshotRates, len(m.netRxRates)),
+		Checks:      make([]snapshotCheck, 0, len(m.statusChecks)),
+		Namespaces:  make([]snapshotNamespace, 0, len(m.namespaces)),
+		Errors:      make([]snapshotError, 0, len(m.errors)),
+	}
+	for name, rx := range m.netRxRates {
+		s.NetRates[name] = snapshotRates{RX: rx, TX: m.netTxRates[name]}
+	}
+	for _, c := range m.statusChecks {
+		s.Checks = append(s.Checks, snapshotCheck{
+			Name:    c.name,
+			Type:    c.config.Type,
+			Target:  c.config.Target,
+			OK:      c.status,
+			Checked: c.checked,
+		})
+	}
+	for _, ns := range m.namespaces {
+		s.Namespaces = append(s.Namespaces, snapshotNamespace{
+			Name:    ns.Name,
+			Phase:   string(ns.Status.Phase),
+			Created: ns.CreationTimestamp.Time,
+		})
+	}
+	for _, e := range m.errors {
+		s.Errors = append(s.Errors, snapshotError{Section: e.section, Collector: e.collector, Error: e.err.Error()})
+	}
+	return s
+}
+
+// writeSnapshotCmd writes s as JSON to a timestamped file in the working
+// directory. It's encoded right away, since s shares maps with the model
+// that later updates write to.
+func writeSnapshotCmd(s dashboardSnapshot) tea.Cmd {
+	b, err := json.MarshalIndent(s, "", "  ")
+	path := fmt.Sprintf("systat-snapshot-%s.json", s.TakenAt.Format("20060102-150405"))
+	return func() tea.Msg {
+		if err != nil {
+			return fileWrittenMsg{path: path, err: fmt.Errorf("failed to encode snapshot: %w", err)}
+		}
+		if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+			return fileWrittenMsg{path: path, err: fmt.Errorf("failed to write snapshot: %w", err)}
+		}
+		return fileWrittenMsg{path: path}
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// statsdMaxPacket keeps datagrams under a typical network MTU.
+const statsdMaxPacket = 1400
+
+// sendStatsd sends every numeric field of points to --statsd as a gauge named
+// <prefix>[.<host>].<measurement>[.<name tag>].<field>, e.g.
+// systat.disk.var_log.used_percent. StatsD has no tags, so the host segment
+// is only added when --host-tag is given.
+// Failures are logged rather than returned so a missing StatsD endpoint
+// never stops a watch loop.
+func sendStatsd(logger *log.Logger, points []influxPoint) {
+	conn, err := net.Dial("udp", statsdAddr)
+	if err != nil {
+		logger.Warn("failed to connect to statsd", "addr", statsdAddr, "error", err)
+		return
+	}
+	defer conn.Close()
+
+	var packet bytes.Buffer
+	flush := func() {
+		if packet.Len() == 0 {
+			return
+		}
+		if _, err := conn.Write(packet.Bytes()); err != nil {
+			logger.Warn("failed to send to statsd", "addr", statsdAddr, "error", err)
+		}
+		packet.Reset()
+	}
+
+	prefix := statsdPrefix
+	if hostTagOverride != "" {
+		prefix += "." + statsdSanitize(hostTagOverride)
+	}
+
+	for _, p := range points {
+		name := prefix + "." + p.measurement
+		if tag, ok := statsdNameTag(p); ok {
+			name += "." + statsdSanitize(tag)
+		}
+
+		for _, field := range p.fields {
+			value, ok := statsdValue(field.value)
+			if !ok {
+				continue
+			}
+
+			line := name + "." + field.key + ":" + value + "|g\n"
+			if packet.Len()+len(line) > statsdMaxPacket {
+				flush()
+			}
+			packet.WriteString(line)
+		}
+	}
+	flush()
+}
+
+// statsdNameTag picks the tag that names a point's metrics: its mountpoint
+// when it has one, as partitions are better known by where they're mounted
+// than by device, and otherwise its first tag.
+func statsdNameTag(p influxPoint) (string, bool) {
+	for _, tag := range p.tags {
+		if tag.key == "mountpoint" {
+			return tag.value, true
+		}
+	}
+	if len(p.tags) == 0 {
+		return "", false
+	}
+	return p.tags[0].value, true
+}
+
+// statsdValue formats a gauge value; non-numeric values are skipped.
+func statsdValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		if v {
+			return "1", true
+		}
+		return "0", true
+	default:
+		return "", false
+	}
+}
+
+// statsdSanitize turns a tag value such as a mountpoint into a single
+// metric name segment: "/" becomes "root" and "/var/log" becomes "var_log".
+func statsdSanitize(s string) string {
+	s = strings.Trim(s, "/")
+	if s == "" {
+		return "root"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
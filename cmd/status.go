@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Run the dashboard's status checks once",
+	Long: `Run the checks shown in the dashboard's Status section and print their
+results. The checks come from "checks" in the config file, the same as the
+dashboard, plus a check per executable in the "check_scripts" directory.
+
+Each check is one of:
+  - dns: the target resolves
+  - ping: the target answers one ICMP echo
+  - tcp: the target host:port accepts a connection
+  - http: a GET of the target URL returns a 2xx status, or expect_status
+  - command: a shell command, with {host} replaced by the target, exits zero
+  - script: a script from "check_scripts" exits zero; the first line it
+    prints is shown as its message. At most 4 scripts run at once.
+
+In watch mode each check runs on its own interval from the config file
+rather than every --interval, and checks not yet due again show their
+previous result.
+
+JSON output has each check's name, type, target, result, latency and
+error, a script's message, and "all_ok" for the checks as a whole.
+
+Exits non-zero if any check fails, so it can be used from cron or CI. In
+watch mode failures are reported each round and alerts fire as they would
+in the dashboard.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+		checks := configuredChecks()
+		schedule := newCheckSchedule(checks)
+
+		var failed int
+		err := runWatch(cmd.Context(), func() error {
+			var err error
+			failed, err = showStatus(cmd.Context(), logger, schedule)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if failed > 0 && !watchOutput {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+// showStatus runs the checks that are due and prints the latest results,
+// returning how many failed.
+func showStatus(ctx context.Context, logger *log.Logger, schedule *checkSchedule) (int, error) {
+	logger.Debug("running status checks", "count", len(schedule.checks))
+	defer timeCollector(logger, "status")()
+
+	results := schedule.Run(ctx)
+
+	var failed []int
+	for i, r := range results {
+		alerts.Check(r.Name, r.OK)
+		if !r.OK {
+			failed = append(failed, i)
+			logger.Debug("check failed", "check", r.Name, "error", r.Error)
+		}
+	}
+
+	if structuredOutput() {
+		return len(failed), renderStructured(statusReport{AllOK: len(failed) == 0, Checks: results})
+	}
+
+	fmt.Println(titleStyle.Render("Status"))
+	columns := []table.Column{
+		{Title: "Check", Width: 30},
+		{Title: "Type", Width: 8},
+		{Title: "Target", Width: 30},
+		{Title: "Status", Width: 6},
+		{Title: "Code", Width: 4},
+		{Title: "Took", Width: 10},
+	}
+	// Only script checks have messages, so the column is left out without
+	// them.
+	showMessage := slices.ContainsFunc(results, func(r checkResult) bool { return r.Message != "" })
+	if showMessage {
+		columns = append(columns, table.Column{Title: "Message", Width: 40})
+	}
+
+	var rows []table.Row
+	for _, r := range results {
+		row := table.Row{
+			r.Name,
+			r.Type,
+			r.Target,
+			getStatusSymbol(r.OK),
+			statusCode(r.Code),
+			r.Took.Round(time.Microsecond).String(),
+		}
+		if showMessage {
+			row = append(row, orDash(r.Message))
+		}
+		rows = append(rows, row)
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, failed)))
+
+	return len(failed), nil
+}
+
+// statusReport is the structured output of the status command.
+type statusReport struct {
+	AllOK  bool          `json:"all_ok"`
+	Checks []checkResult `json:"checks"`
+}
+
+// statusCode shows an http check's status code, or "-" for other checks.
+func statusCode(code int) string {
+	if code == 0 {
+		return "-"
+	}
+	return strconv.Itoa(code)
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -18,8 +20,15 @@ var (
 		BorderForeground(lipgloss.Color("#babbf1")).
 		MarginBottom(1)
 
+	// warnStyle highlights table rows that need attention
+	warnStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e78284"))
+
 	// Helper functions
 	NewTable = func(columns []table.Column, rows []table.Row) table.Model {
+		if len(rows) == 0 {
+			rows = []table.Row{noResultsRow(columns)}
+		}
 		t := table.New(
 			table.WithColumns(columns),
 			table.WithRows(rows),
@@ -29,3 +38,68 @@ var (
 		return t
 	}
 )
+
+// Arrows markSorted adds to the title of the column a table is sorted by.
+// Ascending means smallest, earliest or A first.
+const (
+	sortAscending  = " ▲"
+	sortDescending = " ▼"
+)
+
+// markSorted marks the column titled title as the one the table is sorted
+// by, widening it if the arrow wouldn't fit.
+func markSorted(columns []table.Column, title string, descending bool) []table.Column {
+	arrow := sortAscending
+	if descending {
+		arrow = sortDescending
+	}
+	for i := range columns {
+		if columns[i].Title == title {
+			columns[i].Title += arrow
+			columns[i].Width = max(columns[i].Width, lipgloss.Width(columns[i].Title))
+		}
+	}
+	return columns
+}
+
+// noResults is shown in place of rows when a table is empty, so a filter
+// that excludes everything doesn't leave a blank box.
+const noResults = "no results"
+
+// noResultsRow returns a row holding noResults in the first column wide
+// enough to show it in full.
+func noResultsRow(columns []table.Column) table.Row {
+	row := make(table.Row, len(columns))
+	for i, col := range columns {
+		if col.Width >= len(noResults) {
+			row[i] = noResults
+			return row
+		}
+	}
+	if len(row) > 0 {
+		row[0] = noResults
+	}
+	return row
+}
+
+// orDash shows empty values as "-".
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// highlightRows renders the given rows of a table view built by NewTable in
+// style. Cells are plain text, so whole lines are styled after rendering
+// rather than embedding escape codes the table would count as width.
+func highlightRows(view string, style lipgloss.Style, rows []int) string {
+	lines := strings.Split(view, "\n")
+	for _, row := range rows {
+		// The first line is the header.
+		if i := row + 1; i < len(lines) {
+			lines[i] = style.Render(lines[i])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
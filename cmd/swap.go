@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// showSwapDevices is set by --swap-devices.
+var showSwapDevices bool
+
+type swapDeviceInfo struct {
+	Name        string  `json:"name"`
+	SizeBytes   uint64  `json:"size_bytes"`
+	UsedBytes   uint64  `json:"used_bytes"`
+	FreeBytes   uint64  `json:"free_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
+// collectSwapDevices returns the usage of each swap partition, file or zram
+// device when --swap-devices is set.
+func collectSwapDevices() ([]swapDeviceInfo, error) {
+	if !showSwapDevices {
+		return nil, nil
+	}
+
+	devices, err := mem.SwapDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list swap devices: %w", err)
+	}
+
+	infos := make([]swapDeviceInfo, 0, len(devices))
+	for _, d := range devices {
+		info := swapDeviceInfo{
+			Name:      d.Name,
+			SizeBytes: d.UsedBytes + d.FreeBytes,
+			UsedBytes: d.UsedBytes,
+			FreeBytes: d.FreeBytes,
+		}
+		if info.SizeBytes > 0 {
+			info.UsedPercent = float64(info.UsedBytes) / float64(info.SizeBytes) * 100
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
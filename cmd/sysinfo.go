@@ -18,7 +18,10 @@ Provides information about:
   - OS version and architecture
   - CPU model and features
   - Memory size and configuration
-  - Network interfaces and drivers`,
+  - Network interfaces and drivers
+
+With --fqdn the hostname's fully qualified domain name is looked up and
+shown too.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logger := log.FromContext(cmd.Context())
 		return showSysInfo(logger)
@@ -27,12 +30,13 @@ Provides information about:
 
 func showSysInfo(logger *log.Logger) error {
 	logger.Debug("gathering system information")
+	defer timeCollector(logger, "sysinfo")()
 
 	var si sysinfo.SysInfo
 	si.GetSysInfo()
 
-	if rawOutput {
-		return showRawSysInfo(&si)
+	if structuredOutput() {
+		return showJSONSysInfo(&si)
 	}
 
 	// OS Information
@@ -48,6 +52,11 @@ func showSysInfo(logger *log.Logger) error {
 		{"Kernel", si.Kernel.Release},
 		{"Hostname", si.Node.Hostname},
 	}
+	// Resolving the FQDN takes DNS lookups, which can stall on a host with
+	// broken DNS, so it's only done when asked for.
+	if hostTagFQDN {
+		rows = append(rows, table.Row{"FQDN", fqdn(si.Node.Hostname)})
+	}
 
 	t := NewTable(columns, rows)
 	fmt.Println(tableStyle.Render(t.View()))
@@ -59,7 +68,7 @@ func showSysInfo(logger *log.Logger) error {
 		{"Model", si.CPU.Model},
 		{"Cores", fmt.Sprintf("%d", si.CPU.Cores)},
 		{"Threads", fmt.Sprintf("%d", si.CPU.Threads)},
-		{"Cache", humanize.Bytes(uint64(si.CPU.Cache))},
+		{"Cache", humanize.Bytes(cpuCacheBytes(&si))},
 	}
 
 	t = NewTable(columns, rows)
@@ -68,7 +77,7 @@ func showSysInfo(logger *log.Logger) error {
 	// Memory Information
 	fmt.Println(titleStyle.Render("Memory Information"))
 	rows = []table.Row{
-		{"Total", humanize.Bytes(uint64(si.Memory.Size))},
+		{"Total", humanize.Bytes(memorySizeBytes(&si))},
 	}
 
 	t = NewTable(columns, rows)
@@ -77,26 +86,55 @@ func showSysInfo(logger *log.Logger) error {
 	return nil
 }
 
-func showRawSysInfo(si *sysinfo.SysInfo) error {
-	fmt.Println("Operating System:")
-	fmt.Printf("  OS: %s %s\n", si.OS.Name, si.OS.Version)
-	fmt.Printf("  Architecture: %s\n", si.OS.Architecture)
-	fmt.Printf("  Kernel: %s\n", si.Kernel.Release)
-	fmt.Printf("  Hostname: %s\n", si.Node.Hostname)
-	fmt.Println()
-
-	fmt.Println("CPU Information:")
-	fmt.Printf("  Vendor: %s\n", si.CPU.Vendor)
-	fmt.Printf("  Model: %s\n", si.CPU.Model)
-	fmt.Printf("  Cores: %d\n", si.CPU.Cores)
-	fmt.Printf("  Threads: %d\n", si.CPU.Threads)
-	fmt.Printf("  Cache: %s\n", humanize.Bytes(uint64(si.CPU.Cache)))
-	fmt.Println()
-
-	fmt.Println("Memory Information:")
-	fmt.Printf("  Total: %s\n", humanize.Bytes(uint64(si.Memory.Size)))
+type sysInfo struct {
+	OS struct {
+		Name         string `json:"name"`
+		Version      string `json:"version"`
+		Architecture string `json:"architecture"`
+		Kernel       string `json:"kernel"`
+		Hostname     string `json:"hostname"`
+		FQDN         string `json:"fqdn,omitempty"`
+	} `json:"os"`
+	CPU struct {
+		Vendor     string `json:"vendor"`
+		Model      string `json:"model"`
+		Cores      uint   `json:"cores"`
+		Threads    uint   `json:"threads"`
+		CacheBytes uint64 `json:"cache_bytes"`
+	} `json:"cpu"`
+	Memory struct {
+		TotalBytes uint64 `json:"total_bytes"`
+	} `json:"memory"`
+}
 
-	return nil
+func showJSONSysInfo(si *sysinfo.SysInfo) error {
+	var info sysInfo
+	info.OS.Name = si.OS.Name
+	info.OS.Version = si.OS.Version
+	info.OS.Architecture = si.OS.Architecture
+	info.OS.Kernel = si.Kernel.Release
+	info.OS.Hostname = si.Node.Hostname
+	if hostTagFQDN {
+		info.OS.FQDN = fqdn(si.Node.Hostname)
+	}
+	info.CPU.Vendor = si.CPU.Vendor
+	info.CPU.Model = si.CPU.Model
+	info.CPU.Cores = si.CPU.Cores
+	info.CPU.Threads = si.CPU.Threads
+	info.CPU.CacheBytes = cpuCacheBytes(si)
+	info.Memory.TotalBytes = memorySizeBytes(si)
+
+	return renderStructured(info)
+}
+
+// cpuCacheBytes converts the CPU cache size, which sysinfo reports in KB.
+func cpuCacheBytes(si *sysinfo.SysInfo) uint64 {
+	return uint64(si.CPU.Cache) * 1024
+}
+
+// memorySizeBytes converts the RAM size, which sysinfo reports in MB.
+func memorySizeBytes(si *sysinfo.SysInfo) uint64 {
+	return uint64(si.Memory.Size) * 1024 * 1024
 }
 
 func init() {
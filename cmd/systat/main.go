@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/charmbracelet/log"
 	"github.com/noxsios/systat/cmd"
@@ -13,12 +15,16 @@ func main() {
 		ReportTimestamp: false,
 		ReportCaller:    false,
 	})
-	ctx := context.Background()
+	// SIGINT and SIGTERM cancel the context, so watch mode and servers stop
+	// cleanly under systemd or in a container
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	ctx = log.WithContext(ctx, logger)
 
 	if err := cmd.ExecuteContext(ctx); err != nil {
 		logger.Print("")
 		logger.Error(err)
+		stop()
 		os.Exit(1)
 	}
 }
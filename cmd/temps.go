@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/spf13/cobra"
+)
+
+var fahrenheit bool
+
+var tempsCmd = &cobra.Command{
+	Use:   "temps",
+	Short: "Display hardware temperature and fan sensors",
+	Long: `Display temperature sensor readings using github.com/shirou/gopsutil.
+Provides information about:
+  - Current temperature per sensor
+  - High and critical thresholds reported by the hardware
+  - Fan speeds, where exposed by Linux hwmon`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		return runWatch(cmd.Context(), func() error {
+			return showTemps(logger)
+		})
+	},
+}
+
+type tempsInfo struct {
+	Host         string     `json:"host"`
+	Temperatures []tempInfo `json:"temperatures"`
+	Fans         []fanInfo  `json:"fans"`
+}
+
+type tempInfo struct {
+	Sensor          string  `json:"sensor"`
+	CelsiusCurrent  float64 `json:"temperature_celsius"`
+	CelsiusHigh     float64 `json:"high_celsius"`
+	CelsiusCritical float64 `json:"critical_celsius"`
+}
+
+type fanInfo struct {
+	Sensor string `json:"sensor"`
+	RPM    uint64 `json:"rpm"`
+}
+
+func showTemps(logger *log.Logger) error {
+	logger.Debug("gathering temperature sensors")
+	defer timeCollector(logger, "temps")()
+
+	temps, err := host.SensorsTemperatures()
+	if err != nil {
+		// gopsutil returns readings alongside warnings for sensors it
+		// couldn't read, so only fail when nothing came back.
+		if len(temps) == 0 {
+			return fmt.Errorf("failed to read temperature sensors: %w", err)
+		}
+		logger.Debug("some temperature sensors could not be read", "error", err)
+	}
+
+	fans, err := readFans()
+	if err != nil {
+		logger.Debug("failed to read fan sensors", "error", err)
+	}
+
+	if structuredOutput() {
+		info := tempsInfo{
+			Host:         hostTag(),
+			Temperatures: make([]tempInfo, 0, len(temps)),
+			Fans:         fans,
+		}
+		if info.Fans == nil {
+			info.Fans = []fanInfo{}
+		}
+		for _, t := range temps {
+			info.Temperatures = append(info.Temperatures, tempInfo{
+				Sensor:          t.SensorKey,
+				CelsiusCurrent:  t.Temperature,
+				CelsiusHigh:     t.High,
+				CelsiusCritical: t.Critical,
+			})
+		}
+		return renderStructured(info)
+	}
+
+	fmt.Println(titleStyle.Render("Temperatures"))
+	columns := []table.Column{
+		{Title: "Sensor", Width: 30},
+		{Title: "Current", Width: 10},
+		{Title: "High", Width: 10},
+		{Title: "Critical", Width: 10},
+	}
+
+	var rows []table.Row
+	for _, t := range temps {
+		rows = append(rows, table.Row{
+			t.SensorKey,
+			formatTemp(t.Temperature),
+			formatTemp(t.High),
+			formatTemp(t.Critical),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+
+	fmt.Println(titleStyle.Render("Fans"))
+	if len(fans) == 0 {
+		fmt.Println("no fan sensors")
+		return nil
+	}
+
+	fanColumns := []table.Column{
+		{Title: "Sensor", Width: 30},
+		{Title: "Speed", Width: 10},
+	}
+
+	var fanRows []table.Row
+	for _, f := range fans {
+		fanRows = append(fanRows, table.Row{
+			f.Sensor,
+			strconv.FormatUint(f.RPM, 10) + " RPM",
+		})
+	}
+
+	ft := NewTable(fanColumns, fanRows)
+	fmt.Println(tableStyle.Render(ft.View()))
+
+	return nil
+}
+
+// hwmonRoot is where Linux exposes hardware monitoring chips. gopsutil
+// doesn't read fan sensors, so they're taken from here directly.
+const hwmonRoot = "/sys/class/hwmon"
+
+// readFans returns the fan speeds reported by hwmon, named after the chip
+// and the fan's label when the driver provides one. On systems without
+// hwmon it returns no fans.
+func readFans() ([]fanInfo, error) {
+	inputs, err := filepath.Glob(filepath.Join(hwmonRoot, "*", "fan*_input"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fan sensors: %w", err)
+	}
+
+	var fans []fanInfo
+	for _, input := range inputs {
+		rpm, err := readSysfsUint(input)
+		if err != nil {
+			continue
+		}
+
+		dir := filepath.Dir(input)
+		fan := strings.TrimSuffix(filepath.Base(input), "_input")
+		if label, err := os.ReadFile(filepath.Join(dir, fan+"_label")); err == nil {
+			fan = strings.TrimSpace(string(label))
+		}
+		sensor := fan
+		if chip, err := os.ReadFile(filepath.Join(dir, "name")); err == nil {
+			sensor = strings.TrimSpace(string(chip)) + "_" + fan
+		}
+
+		fans = append(fans, fanInfo{Sensor: sensor, RPM: rpm})
+	}
+
+	sort.SliceStable(fans, func(i, j int) bool {
+		return fans[i].Sensor < fans[j].Sensor
+	})
+	return fans, nil
+}
+
+func readSysfsUint(path string) (uint64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+}
+
+// formatTemp renders a Celsius reading in the unit chosen by --fahrenheit.
+// Sensors report 0 for thresholds they don't have, which is shown as "-".
+func formatTemp(celsius float64) string {
+	if celsius == 0 {
+		return "-"
+	}
+	if fahrenheit {
+		return fmt.Sprintf("%.1f°F", celsius*9/5+32)
+	}
+	return fmt.Sprintf("%.1f°C", celsius)
+}
+
+func init() {
+	tempsCmd.Flags().BoolVar(&fahrenheit, "fahrenheit", false, "show temperatures in degrees Fahrenheit")
+	rootCmd.AddCommand(tempsCmd)
+}
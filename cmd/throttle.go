@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// throttleMarginC is how close to its critical temperature, in Celsius, a
+// CPU sensor must be to count as hot.
+const throttleMarginC = 10
+
+// cpuSensorPrefixes pick out CPU temperature sensors, so a hot disk or GPU
+// isn't mistaken for a hot CPU.
+var cpuSensorPrefixes = []string{"coretemp", "k10temp", "zenpower", "cpu"}
+
+// throttleInfo is whether the CPU looks thermally throttled: a CPU sensor
+// near its critical temperature while cores run below their base clock.
+type throttleInfo struct {
+	Throttling     bool    `json:"throttling"`
+	Sensor         string  `json:"sensor,omitempty"`
+	CelsiusCurrent float64 `json:"temperature_celsius,omitempty"`
+	// CelsiusLimit is the sensor's critical temperature, or its high one
+	// when it has no critical threshold.
+	CelsiusLimit float64 `json:"limit_celsius,omitempty"`
+	SlowCPUs     []int   `json:"slow_cpus,omitempty"`
+	TotalCPUs    int     `json:"total_cpus"`
+}
+
+// detectThrottling correlates temperature sensors with CPU frequencies. It
+// returns nil when there isn't enough to go on: no CPU sensor, or no
+// frequencies with a known base clock.
+func detectThrottling(temps []host.TemperatureStat, freqs []cpuFreq) *throttleInfo {
+	var hottest *host.TemperatureStat
+	for i, t := range temps {
+		if !isCPUSensor(t.SensorKey) {
+			continue
+		}
+		if hottest == nil || headroom(t) < headroom(*hottest) {
+			hottest = &temps[i]
+		}
+	}
+	if hottest == nil {
+		return nil
+	}
+
+	info := &throttleInfo{}
+	for _, f := range freqs {
+		if f.BaseMHz == 0 {
+			continue
+		}
+		info.TotalCPUs++
+		if f.CurMHz < f.BaseMHz {
+			info.SlowCPUs = append(info.SlowCPUs, f.CPU)
+		}
+	}
+	if info.TotalCPUs == 0 {
+		return nil
+	}
+
+	if headroom(*hottest) <= throttleMarginC && len(info.SlowCPUs) > 0 {
+		info.Throttling = true
+		info.Sensor = hottest.SensorKey
+		info.CelsiusCurrent = hottest.Temperature
+		info.CelsiusLimit = hottest.Critical
+		if info.CelsiusLimit == 0 {
+			info.CelsiusLimit = hottest.High
+		}
+	}
+	return info
+}
+
+// Summary describes the throttling for a warning line.
+func (t throttleInfo) Summary() string {
+	return fmt.Sprintf("CPU thermal throttling: %s at %s (limit %s), %d of %d CPUs below base clock",
+		t.Sensor, formatTemp(t.CelsiusCurrent), formatTemp(t.CelsiusLimit), len(t.SlowCPUs), t.TotalCPUs)
+}
+
+// readThrottling reads the sensors and frequencies detectThrottling needs.
+// Like detectThrottling it returns nil when the system doesn't expose them.
+func readThrottling() (*throttleInfo, error) {
+	freqs, err := readCPUFreqs()
+	if err != nil || len(freqs) == 0 {
+		return nil, err
+	}
+	return throttlingFrom(freqs)
+}
+
+// throttlingFrom is readThrottling for callers that already read freqs.
+func throttlingFrom(freqs []cpuFreq) (*throttleInfo, error) {
+	temps, err := host.SensorsTemperatures()
+	// Readings can come back alongside warnings for other sensors.
+	if len(temps) == 0 && err != nil {
+		return nil, fmt.Errorf("failed to read temperature sensors: %w", err)
+	}
+	return detectThrottling(temps, freqs), nil
+}
+
+// headroom is how far a sensor is below its critical temperature, or below
+// its high one for sensors without a critical threshold. Sensors with
+// neither never count as near their limit.
+func headroom(t host.TemperatureStat) float64 {
+	switch {
+	case t.Critical > 0:
+		return t.Critical - t.Temperature
+	case t.High > 0:
+		return t.High - t.Temperature
+	default:
+		return throttleMarginC + 1
+	}
+}
+
+func isCPUSensor(key string) bool {
+	for _, prefix := range cpuSensorPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
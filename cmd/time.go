@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Display system time and NTP synchronization",
+	Long: `Display the system clock, timezone and whether the kernel considers the
+clock synchronized by NTP (chrony, ntpd or systemd-timesyncd), with the
+estimated error. An unsynchronized clock is highlighted, since drift causes
+hard-to-trace failures in TLS, Kerberos and distributed systems.
+
+NTP status is read with adjtimex and is only available on Linux.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		return runWatch(cmd.Context(), func() error {
+			return showTime(logger)
+		})
+	},
+}
+
+type timeInfo struct {
+	Host      string    `json:"host"`
+	Time      time.Time `json:"time"`
+	Timezone  string    `json:"timezone"`
+	UTCOffset string    `json:"utc_offset"`
+	NTP       ntpStatus `json:"ntp"`
+}
+
+// ntpStatus is the kernel's view of clock discipline. The figures are only
+// meaningful when Supported is true.
+type ntpStatus struct {
+	Supported    bool    `json:"supported"`
+	Synchronized bool    `json:"synchronized"`
+	OffsetMs     float64 `json:"offset_ms"`
+	MaxErrorMs   float64 `json:"max_error_ms"`
+	EstErrorMs   float64 `json:"est_error_ms"`
+}
+
+func showTime(logger *log.Logger) error {
+	logger.Debug("gathering system time")
+	defer timeCollector(logger, "time")()
+
+	now := time.Now()
+	info := timeInfo{
+		Host:      hostTag(),
+		Time:      now,
+		Timezone:  timezoneName(now),
+		UTCOffset: now.Format("-07:00"),
+	}
+
+	ntp, err := readNTPStatus()
+	if err != nil {
+		logger.Debug("failed to read NTP status", "error", err)
+	}
+	info.NTP = ntp
+	if ntp.Supported {
+		alerts.Check("ntp", ntp.Synchronized)
+	}
+
+	if structuredOutput() {
+		return renderStructured(info)
+	}
+
+	rows := []table.Row{
+		{"Time", now.Format(time.RFC3339)},
+		{"UTC", now.UTC().Format(time.RFC3339)},
+		{"Timezone", info.Timezone + " (UTC" + info.UTCOffset + ")"},
+	}
+	var unsynced []int
+	if ntp.Supported {
+		synced := "yes"
+		if !ntp.Synchronized {
+			synced = "NO"
+			unsynced = append(unsynced, len(rows))
+		}
+		rows = append(rows,
+			table.Row{"NTP Synchronized", synced},
+			table.Row{"Offset", formatMs(ntp.OffsetMs)},
+			table.Row{"Max Error", formatMs(ntp.MaxErrorMs)},
+			table.Row{"Est. Error", formatMs(ntp.EstErrorMs)},
+		)
+	} else {
+		rows = append(rows, table.Row{"NTP Synchronized", "unknown"})
+	}
+
+	fmt.Println(titleStyle.Render("System Time"))
+	columns := []table.Column{
+		{Title: "Property", Width: 20},
+		{Title: "Value", Width: 40},
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(highlightRows(t.View(), warnStyle, unsynced)))
+
+	return nil
+}
+
+// timezoneName returns the IANA name of the local timezone, such as
+// Europe/Berlin, falling back to its abbreviation.
+func timezoneName(now time.Time) string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return strings.TrimPrefix(tz, ":")
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+			return name
+		}
+	}
+	name, _ := now.Zone()
+	return name
+}
+
+func formatMs(ms float64) string {
+	return fmt.Sprintf("%.3fms", ms)
+}
+
+func init() {
+	rootCmd.AddCommand(timeCmd)
+}
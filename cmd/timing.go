@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// timeCollector logs at debug level how long a collector took, to find what
+// is slowing a refresh down. Use it as
+//
+//	defer timeCollector(logger, "disk")()
+func timeCollector(logger *log.Logger, name string) func() {
+	start := time.Now()
+	return func() {
+		logger.Debug("collector finished", "collector", name, "took", time.Since(start).Round(time.Microsecond))
+	}
+}
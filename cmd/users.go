@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/log"
+	"github.com/dustin/go-humanize"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/spf13/cobra"
+)
+
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "List logged-in users and their sessions",
+	Long: `List active login sessions from utmp using github.com/shirou/gopsutil:
+who is logged in, on which terminal, from where, and since when.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := log.FromContext(cmd.Context())
+
+		return runWatch(cmd.Context(), func() error {
+			return showUsers(logger)
+		})
+	},
+}
+
+type userSession struct {
+	User     string    `json:"user"`
+	Terminal string    `json:"terminal"`
+	Host     string    `json:"host"`
+	LoginAt  time.Time `json:"login_time"`
+}
+
+func showUsers(logger *log.Logger) error {
+	logger.Debug("gathering user sessions")
+	defer timeCollector(logger, "users")()
+
+	users, err := host.Users()
+	if err != nil {
+		// Containers and minimal systems often have no utmp at all, which
+		// just means nobody is logged in.
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to get user sessions: %w", err)
+		}
+		logger.Debug("no utmp file, so no sessions", "error", err)
+	}
+
+	sessions := make([]userSession, 0, len(users))
+	for _, u := range users {
+		sessions = append(sessions, userSession{
+			User:     u.User,
+			Terminal: u.Terminal,
+			Host:     u.Host,
+			LoginAt:  time.Unix(int64(u.Started), 0),
+		})
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LoginAt.Before(sessions[j].LoginAt)
+	})
+
+	if structuredOutput() {
+		return renderStructured(sessions)
+	}
+
+	fmt.Println(titleStyle.Render("Logged-in Users"))
+	columns := []table.Column{
+		{Title: "User", Width: 15},
+		{Title: "Terminal", Width: 10},
+		{Title: "From", Width: 30},
+		{Title: "Login", Width: 20},
+	}
+	columns = markSorted(columns, "Login", false)
+
+	var rows []table.Row
+	for _, s := range sessions {
+		rows = append(rows, table.Row{
+			s.User,
+			s.Terminal,
+			sessionHost(s.Host),
+			humanize.Time(s.LoginAt),
+		})
+	}
+
+	t := NewTable(columns, rows)
+	fmt.Println(tableStyle.Render(t.View()))
+
+	return nil
+}
+
+// sessionHost returns where a session came from; local logins have none.
+func sessionHost(host string) string {
+	if host == "" {
+		return "local"
+	}
+	return host
+}
+
+func init() {
+	rootCmd.AddCommand(usersCmd)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// runWatch calls fn once, or repeatedly every --interval when --watch is set.
+//
+// With --align the next sample is taken at the next multiple of the interval
+// on the wall clock rather than a fixed delay after the previous one finished,
+// so time spent gathering doesn't accumulate as drift.
+//
+// Each run is recorded for /healthz under the command's name. Frames drawn
+// as tables end with a footer of whatever the command put in watchStats.
+func runWatch(ctx context.Context, fn func() error) error {
+	fn = recordHealth(commandName, fn)
+	if !watchOutput {
+		return fn()
+	}
+	health.Expect(watchInterval)
+
+	if isTerminal(os.Stdout) {
+		fmt.Print("\033[H\033[2J") // Start from a clear screen
+	}
+	for {
+		started := time.Now()
+		frame, err := captureStdout(fn)
+		if !watchStats.Empty() && !structuredOutput() && !pointOutput() {
+			frame = append(frame, watchStats.View()+"\n"...)
+		}
+		redraw(frame)
+		if err != nil {
+			return err
+		}
+
+		if !waitInterval(ctx, started) {
+			return nil
+		}
+	}
+}
+
+// recordHealth wraps fn to record each run with health.
+func recordHealth(name string, fn func() error) func() error {
+	return func() error {
+		start := time.Now()
+		err := fn()
+		health.Collected(name, time.Since(start), err)
+		return err
+	}
+}
+
+// captureStdout runs fn with its output going to a buffer instead of the
+// terminal, so a watch-mode frame can be written in one go.
+func captureStdout(fn func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fn()
+	}
+
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		r.Close()
+		done <- b
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	err = fn()
+	os.Stdout = stdout
+	w.Close()
+
+	return <-done, err
+}
+
+// redraw replaces the previous watch-mode frame with frame in place. Rather
+// than clearing the screen and drawing again, which flashes blank on every
+// refresh, it moves the cursor home, overwrites each line, clears whatever
+// the previous, longer line left behind, and finally clears any lines below.
+//
+// When stdout isn't a terminal, such as with --output-file, frames are
+// appended one after another instead.
+func redraw(frame []byte) {
+	if !isTerminal(os.Stdout) {
+		_, _ = os.Stdout.Write(append(frame, '\n'))
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\033[H")
+	buf.Write(bytes.ReplaceAll(frame, []byte("\n"), []byte("\033[K\n")))
+	buf.WriteString("\033[J")
+	_, _ = os.Stdout.Write(buf.Bytes())
+}
+
+// waitInterval blocks until the next sample is due according to --interval
+// and --align. It returns false if ctx is cancelled first.
+//
+// Without --align the next sample is due an interval after started, when the
+// previous one began, so time spent collecting and drawing comes out of the
+// wait rather than stretching every interval. A sample that overran starts
+// the next one straight away.
+func waitInterval(ctx context.Context, started time.Time) bool {
+	wait := max(0, watchInterval-time.Since(started))
+	if alignWatch {
+		wait = untilNextBoundary(time.Now(), watchInterval)
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(wait):
+		return true
+	}
+}
+
+// untilNextBoundary returns how long to wait from now until the next
+// multiple of interval, e.g. the next :00, :10, :20 for a 10s interval.
+func untilNextBoundary(now time.Time, interval time.Duration) time.Duration {
+	return now.Truncate(interval).Add(interval).Sub(now)
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// blockRoot is where Linux lists block devices, zram ones included.
+const blockRoot = "/sys/block"
+
+// zramInfo is a zram device's compressed memory usage. Stored is how much
+// data was written to the device and Compressed what it takes up once
+// compressed; MemUsed adds the allocator's overhead and is what the device
+// actually costs in RAM.
+type zramInfo struct {
+	Device          string  `json:"device"`
+	Algorithm       string  `json:"algorithm,omitempty"`
+	DiskSizeBytes   uint64  `json:"disk_size_bytes"`
+	StoredBytes     uint64  `json:"stored_bytes"`
+	CompressedBytes uint64  `json:"compressed_bytes"`
+	MemUsedBytes    uint64  `json:"mem_used_bytes"`
+	Ratio           float64 `json:"compression_ratio"`
+}
+
+// readZram returns the zram devices in use, ordered by name. Systems without
+// zram have none.
+func readZram() ([]zramInfo, error) {
+	dirs, err := filepath.Glob(filepath.Join(blockRoot, "zram*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zram devices: %w", err)
+	}
+
+	var devices []zramInfo
+	for _, dir := range dirs {
+		device, err := readZramDevice(dir)
+		if err != nil {
+			return nil, err
+		}
+		// Unconfigured devices (zramctl --find without a size) hold
+		// nothing worth showing.
+		if device.DiskSizeBytes == 0 {
+			continue
+		}
+		devices = append(devices, device)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Device < devices[j].Device
+	})
+	return devices, nil
+}
+
+// readZramDevice reads a device's mm_stat, whose first three fields are the
+// original data size, compressed data size and total memory used, in bytes:
+//
+//	65536    220    12288        0    12288        0        0        0        0
+func readZramDevice(dir string) (zramInfo, error) {
+	device := zramInfo{Device: filepath.Base(dir)}
+
+	size, err := readSysfsUint(filepath.Join(dir, "disksize"))
+	if err != nil {
+		return device, fmt.Errorf("failed to read %s size: %w", device.Device, err)
+	}
+	device.DiskSizeBytes = size
+	if size == 0 {
+		return device, nil
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "mm_stat"))
+	if err != nil {
+		return device, fmt.Errorf("failed to read %s mm_stat: %w", device.Device, err)
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) < 3 {
+		return device, fmt.Errorf("failed to parse %s mm_stat: too few fields", device.Device)
+	}
+	values := make([]uint64, 3)
+	for i := range values {
+		if values[i], err = strconv.ParseUint(fields[i], 10, 64); err != nil {
+			return device, fmt.Errorf("failed to parse %s mm_stat: %w", device.Device, err)
+		}
+	}
+	device.StoredBytes, device.CompressedBytes, device.MemUsedBytes = values[0], values[1], values[2]
+	if device.CompressedBytes > 0 {
+		device.Ratio = float64(device.StoredBytes) / float64(device.CompressedBytes)
+	}
+
+	// comp_algorithm lists the available algorithms with the current one in
+	// brackets, e.g. "lzo [lz4] zstd".
+	if b, err := os.ReadFile(filepath.Join(dir, "comp_algorithm")); err == nil {
+		for _, alg := range strings.Fields(string(b)) {
+			if strings.HasPrefix(alg, "[") {
+				device.Algorithm = strings.Trim(alg, "[]")
+			}
+		}
+	}
+	return device, nil
+}